@@ -2,4 +2,4 @@ module foxhole
 
 go 1.15
 
-require github.com/gitchander/permutation v0.0.0-20210517125447-a5d73722e1b1 // indirect
+require github.com/gitchander/permutation v0.0.0-20210517125447-a5d73722e1b1
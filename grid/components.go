@@ -0,0 +1,217 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"sort"
+)
+
+/*
+	Splits the grid definition into its strongly connected
+	components: groups of cells where the fox can get from any
+	cell in the group to any other. For grids which aren't
+	directed these are just the connected components. Each
+	component is sorted and the components are ordered by their
+	lowest cell.
+*/
+func (d *GridDefinition) StronglyConnectedComponents() [][]int {
+
+	// Tarjan's algorithm
+	n := len(d.Connections)
+	indexes := make([]int, n)
+	lowLinks := make([]int, n)
+	onStack := make([]bool, n)
+	for i := range indexes {
+		indexes[i] = -1
+	}
+
+	stack := []int{}
+	components := [][]int{}
+	index := 0
+
+	var strongConnect func(cell int)
+	strongConnect = func(cell int) {
+
+		indexes[cell] = index
+		lowLinks[cell] = index
+		index++
+		stack = append(stack, cell)
+		onStack[cell] = true
+
+		for _, j := range d.Connections[cell] {
+			if indexes[j] == -1 {
+				strongConnect(j)
+				if lowLinks[j] < lowLinks[cell] {
+					lowLinks[cell] = lowLinks[j]
+				}
+			} else if onStack[j] && indexes[j] < lowLinks[cell] {
+				lowLinks[cell] = indexes[j]
+			}
+		}
+
+		// The cell is the root of a component, so pop the whole component
+		if lowLinks[cell] == indexes[cell] {
+			component := []int{}
+			for {
+				top := stack[len(stack)-1]
+				stack = stack[:len(stack)-1]
+				onStack[top] = false
+				component = append(component, top)
+				if top == cell {
+					break
+				}
+			}
+			components = append(components, component)
+		}
+
+	}
+
+	for cell := 0; cell < n; cell++ {
+		if indexes[cell] == -1 {
+			strongConnect(cell)
+		}
+	}
+
+	// Sort everything so the result doesn't depend on the search order
+	for _, component := range components {
+		sort.Ints(component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+
+	return components
+
+}
+
+/*
+	Determines if the fox can get from any cell to any other cell
+*/
+func (d *GridDefinition) IsStronglyConnected() bool {
+	return len(d.StronglyConnectedComponents()) <= 1
+}
+
+/*
+	Splits the grid definition into groups of cells the fox can
+	never move between, ignoring the direction of connections and
+	counting every day of the schedule. Each group can be hunted
+	on its own. Sorted the same way as StronglyConnectedComponents.
+*/
+func (d *GridDefinition) ConnectedComponents() [][]int {
+
+	// Union find over every connection on every day
+	parents := make([]int, len(d.Connections))
+	for i := range parents {
+		parents[i] = i
+	}
+	var find func(cell int) int
+	find = func(cell int) int {
+		if parents[cell] != cell {
+			parents[cell] = find(parents[cell])
+		}
+		return parents[cell]
+	}
+
+	for _, connections := range append([][][]int{d.Connections}, d.Schedule...) {
+		for i, node := range connections {
+			for _, j := range node {
+				parents[find(i)] = find(j)
+			}
+		}
+	}
+
+	groups := map[int][]int{}
+	for cell := range d.Connections {
+		root := find(cell)
+		groups[root] = append(groups[root], cell)
+	}
+
+	components := [][]int{}
+	for _, component := range groups {
+		components = append(components, component)
+	}
+	sort.Slice(components, func(i, j int) bool {
+		return components[i][0] < components[j][0]
+	})
+
+	return components
+
+}
+
+/*
+	Creates the grid definition made up of just the given cells
+	(which should be sorted). Cell i of the new definition is
+	cells[i] of this one. Symmetries which map the cells onto
+	themselves are kept.
+*/
+func (d *GridDefinition) Subgrid(cells []int) *GridDefinition {
+
+	indexes := map[int]int{}
+	for i, cell := range cells {
+		indexes[cell] = i
+	}
+
+	subConnections := func(connections [][]int) [][]int {
+		newConnections := [][]int{}
+		for _, cell := range cells {
+			node := []int{}
+			for _, j := range connections[cell] {
+				if index, exists := indexes[j]; exists {
+					node = append(node, index)
+				}
+			}
+			newConnections = append(newConnections, node)
+		}
+		return newConnections
+	}
+
+	subgrid := &GridDefinition{
+		Connections:     subConnections(d.Connections),
+		Directed:        d.Directed,
+		ScheduleRepeats: d.ScheduleRepeats,
+	}
+
+	for _, connections := range d.Schedule {
+		subgrid.Schedule = append(subgrid.Schedule, subConnections(connections))
+	}
+
+	if d.Coordinates != nil {
+		for _, cell := range cells {
+			subgrid.Coordinates = append(subgrid.Coordinates, d.Coordinates[cell])
+		}
+	}
+	if len(d.Costs) > 0 {
+		for _, cell := range cells {
+			subgrid.Costs = append(subgrid.Costs, d.Costs[cell])
+		}
+	}
+	for _, exit := range d.Exits {
+		if index, exists := indexes[exit]; exists {
+			subgrid.Exits = append(subgrid.Exits, index)
+		}
+	}
+
+	symmetryHashes := map[string]bool{}
+symmetryLoop:
+	for _, symmetry := range d.Symmetries {
+
+		ordering := []int{}
+		for _, cell := range cells {
+			index, exists := indexes[symmetry[cell]]
+			if !exists {
+				continue symmetryLoop
+			}
+			ordering = append(ordering, index)
+		}
+
+		hash := hashSymmetry(ordering)
+		if !symmetryHashes[hash] {
+			symmetryHashes[hash] = true
+			subgrid.Symmetries = append(subgrid.Symmetries, ordering)
+		}
+
+	}
+
+	return subgrid
+
+}
@@ -0,0 +1,98 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestStronglyConnectedComponents(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		board *GridDefinition
+		want  [][]int
+	}{
+		{"cycle", CreateCycleGrid(4), [][]int{{0, 1, 2, 3}}},
+		{"directed cycle", CreateDirectedCycleGrid(4), [][]int{{0, 1, 2, 3}}},
+
+		// Once the fox leaves the loop it can't come back
+		{"loop with a tail", parseBoard(t, "0 -> 1\n1 -> 2\n2 -> 0\n2 -> 3\n3 - 4"), [][]int{{0, 1, 2}, {3, 4}}},
+		{"one way path", parseBoard(t, "0 -> 1\n1 -> 2"), [][]int{{0}, {1}, {2}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			components := test.board.StronglyConnectedComponents()
+			if !reflect.DeepEqual(components, test.want) {
+				t.Errorf("got components %v, want %v", components, test.want)
+			}
+			if test.board.IsStronglyConnected() != (len(test.want) == 1) {
+				t.Errorf("strongly connected is %v with components %v", test.board.IsStronglyConnected(), test.want)
+			}
+		})
+	}
+
+}
+
+func TestConnectedComponents(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		board *GridDefinition
+		want  [][]int
+	}{
+		{"path", CreateLinearGrid(3), [][]int{{0, 1, 2}}},
+
+		// Direction doesn't matter for pieces the fox can't move between
+		{"loop with a tail", parseBoard(t, "0 -> 1\n1 -> 2\n2 -> 0\n2 -> 3\n3 - 4"), [][]int{{0, 1, 2, 3, 4}}},
+		{"pieces", parseBoard(t, "holes 6\n0 - 3\n1 - 4"), [][]int{{0, 3}, {1, 4}, {2}, {5}}},
+
+		// Tunnels which only open some days still join the pieces
+		{"scheduled", CreateScheduledGrid(parseBoard(t, "holes 3\n0 - 1"), [][][]int{{{1}, {0}, {}}, {{}, {2}, {1}}}, true), [][]int{{0, 1, 2}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if components := test.board.ConnectedComponents(); !reflect.DeepEqual(components, test.want) {
+				t.Errorf("got components %v, want %v", components, test.want)
+			}
+		})
+	}
+
+}
+
+func TestSubgrid(t *testing.T) {
+
+	d, err := CreateMaskedGrid("...#..")
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.SetExits(4)
+
+	// The right hand piece is a path of 2 with its exit renumbered
+	sub := d.Subgrid([]int{3, 4})
+	if err := sub.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if want := [][]int{{1}, {0}}; !reflect.DeepEqual(sub.Connections, want) {
+		t.Errorf("got connections %v, want %v", sub.Connections, want)
+	}
+	if want := []int{1}; !reflect.DeepEqual(sub.Exits, want) {
+		t.Errorf("got exits %v, want %v", sub.Exits, want)
+	}
+	if want := [][]int{d.Coordinates[3], d.Coordinates[4]}; !reflect.DeepEqual(sub.Coordinates, want) {
+		t.Errorf("got coordinates %v, want %v", sub.Coordinates, want)
+	}
+
+	// Each row keeps the reflection of the board, but not the one swapping the rows
+	rows, err := CreateMaskedGrid("...\n###\n...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if top := rows.Subgrid([]int{0, 1, 2}); len(top.Symmetries) != 2 {
+		t.Errorf("got %d symmetries for the top row, want 2", len(top.Symmetries))
+	}
+
+}
@@ -0,0 +1,204 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"fmt"
+)
+
+/*
+	Restrictions on which holes can be checked together on a day
+*/
+type CheckConstraints struct {
+
+	// Holes which can never be checked
+	Forbidden map[int]bool
+
+	// Whether the holes checked each day have to be connected to each other
+	Connected bool
+
+	/*
+		How far apart every pair of holes checked on the same day
+		has to be. 0 or 1 means there's no restriction.
+	*/
+	MinDistance int
+}
+
+/*
+	The constraints used by every solver. By default any set
+	of holes can be checked.
+*/
+var Constraints = CheckConstraints{}
+
+/*
+	Sets the constraints used by every solver. Forbidden holes can
+	break some of the symmetries of the BaseGrid, so those are
+	removed. Should be called after BaseGrid is set. Returns an
+	error (leaving the constraints as they were) if a forbidden
+	hole isn't part of the BaseGrid.
+*/
+func SetConstraints(constraints CheckConstraints) error {
+
+	for hole := range constraints.Forbidden {
+		if hole < 0 || hole >= len(BaseGrid.Connections) {
+			return fmt.Errorf("forbidden hole %d isn't one of the %d holes", hole, len(BaseGrid.Connections))
+		}
+	}
+
+	Constraints = constraints
+
+	// Holes which can't be checked have to be mapped onto each other
+	symmetries := [][]int{}
+	for _, symmetry := range BaseGrid.Symmetries {
+		keep := true
+		for hole := range constraints.Forbidden {
+			if !constraints.Forbidden[symmetry[hole]] {
+				keep = false
+				break
+			}
+		}
+		if keep {
+			symmetries = append(symmetries, symmetry)
+		}
+	}
+	BaseGrid.Symmetries = symmetries
+
+	return nil
+
+}
+
+/*
+	Whether or not a set of checks can be made on the same day
+*/
+func (constraints CheckConstraints) Allows(checks map[int]bool) bool {
+	if !constraints.AllowsPartial(checks) {
+		return false
+	}
+	return !constraints.Connected || BaseGrid.IsConnectedSet(checks)
+}
+
+/*
+	Whether or not a set of checks could still be part of a set
+	which is allowed. Forbidden holes and holes which are too close
+	can't be fixed by checking more, but disconnected checks can.
+*/
+func (constraints CheckConstraints) AllowsPartial(checks map[int]bool) bool {
+
+	for hole := range checks {
+		if constraints.Forbidden[hole] {
+			return false
+		}
+	}
+
+	if constraints.MinDistance > 1 {
+		distances := BaseGrid.Distances()
+		for a := range checks {
+			for b := range checks {
+				if a >= b {
+					continue
+				}
+				if distance := pairDistance(distances, a, b); distance != -1 && distance < constraints.MinDistance {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+
+}
+
+/*
+	Distance between two cells going whichever way is shorter
+*/
+func pairDistance(distances [][]int, a, b int) int {
+	ab, ba := distances[a][b], distances[b][a]
+	if ab == -1 || (ba != -1 && ba < ab) {
+		return ba
+	}
+	return ab
+}
+
+/*
+	Whether or not every cell in the set can be reached from every
+	other cell while staying inside the set. Connections are used in
+	either direction.
+*/
+func (d *GridDefinition) IsConnectedSet(cells map[int]bool) bool {
+
+	if len(cells) <= 1 {
+		return true
+	}
+
+	// Neighbors in either direction, only inside the set
+	neighbors := map[int][]int{}
+	for cell := range cells {
+		for _, j := range d.Connections[cell] {
+			if cells[j] {
+				neighbors[cell] = append(neighbors[cell], j)
+				neighbors[j] = append(neighbors[j], cell)
+			}
+		}
+	}
+
+	var start int
+	for cell := range cells {
+		start = cell
+		break
+	}
+
+	visited := map[int]bool{start: true}
+	queue := []int{start}
+	for len(queue) > 0 {
+		cell := queue[0]
+		queue = queue[1:]
+		for _, j := range neighbors[cell] {
+			if !visited[j] {
+				visited[j] = true
+				queue = append(queue, j)
+			}
+		}
+	}
+
+	return len(visited) == len(cells)
+
+}
+
+/*
+	Returns every cell within the given number of connections of any
+	of the cells, using connections in either direction. These are
+	the holes which could join the cells up into a connected set.
+*/
+func (d *GridDefinition) NearbyCells(cells map[int]bool, radius int) map[int]bool {
+
+	neighbors := make([][]int, len(d.Connections))
+	for i, connections := range d.Connections {
+		for _, j := range connections {
+			neighbors[i] = append(neighbors[i], j)
+			neighbors[j] = append(neighbors[j], i)
+		}
+	}
+
+	nearby := map[int]bool{}
+	frontier := []int{}
+	for cell := range cells {
+		nearby[cell] = true
+		frontier = append(frontier, cell)
+	}
+
+	for step := 0; step < radius; step++ {
+		newFrontier := []int{}
+		for _, cell := range frontier {
+			for _, j := range neighbors[cell] {
+				if !nearby[j] {
+					nearby[j] = true
+					newFrontier = append(newFrontier, j)
+				}
+			}
+		}
+		frontier = newFrontier
+	}
+
+	return nearby
+
+}
@@ -0,0 +1,208 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strconv"
+	"strings"
+)
+
+/*
+	Helper function for creating a cycle of n holes where the
+	fox can only move forward (from hole i to hole i + 1).
+*/
+func CreateDirectedCycleGrid(n int) *GridDefinition {
+
+	connections := [][]int{}
+	coordinates := [][]int{}
+	for i := 0; i < n; i++ {
+		connections = append(connections, []int{(i + 1) % n})
+		coordinates = append(coordinates, []int{i})
+	}
+
+	definition := &GridDefinition{
+		Connections: connections,
+		Coordinates: coordinates,
+		Directed:    true,
+	}
+	definition.Symmetries = definition.FindAutomorphisms()
+
+	return definition
+
+}
+
+/*
+	Reads a grid definition from a file. See ParseGridDefinition
+	for the format.
+*/
+func LoadGridDefinition(path string) (*GridDefinition, error) {
+
+	contents, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseGridDefinition(string(contents))
+
+}
+
+/*
+	Parses a grid definition from a list of tunnels. Each line
+	is one of:
+
+		holes 6     (the number of holes, optional)
+		0 - 1       (a tunnel the fox can use in both directions)
+		1 -> 2      (a tunnel the fox can only use from 1 to 2)
+
+	Anything after a '#' is a comment. If the number of holes
+	isn't given, it's one more than the largest hole used. The
+	definition is directed if any tunnel is one way, and its
+	symmetries are found by searching for every automorphism.
+*/
+func ParseGridDefinition(text string) (*GridDefinition, error) {
+
+	holes := -1
+	tunnels := [][2]int{}
+
+	for lineNumber, line := range strings.Split(text, "\n") {
+
+		if comment := strings.Index(line, "#"); comment != -1 {
+			line = line[:comment]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		// Number of holes
+		if fields[0] == "holes" {
+			if len(fields) != 2 {
+				return nil, fmt.Errorf("line %d: expected 'holes <n>'", lineNumber+1)
+			}
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 0 {
+				return nil, fmt.Errorf("line %d: invalid number of holes %q", lineNumber+1, fields[1])
+			}
+			holes = n
+			continue
+		}
+
+		// Tunnels
+		if len(fields) != 3 || (fields[1] != "-" && fields[1] != "->") {
+			return nil, fmt.Errorf("line %d: expected '<a> - <b>' or '<a> -> <b>'", lineNumber+1)
+		}
+		a, errA := strconv.Atoi(fields[0])
+		b, errB := strconv.Atoi(fields[2])
+		if errA != nil || errB != nil || a < 0 || b < 0 {
+			return nil, fmt.Errorf("line %d: invalid hole in %q", lineNumber+1, strings.TrimSpace(line))
+		}
+
+		tunnels = append(tunnels, [2]int{a, b})
+		if fields[1] == "-" {
+			tunnels = append(tunnels, [2]int{b, a})
+		}
+
+	}
+
+	if holes == -1 {
+		holes = 0
+		for _, tunnel := range tunnels {
+			for _, hole := range tunnel {
+				if hole+1 > holes {
+					holes = hole + 1
+				}
+			}
+		}
+	}
+
+	// Build the connections, ignoring repeated tunnels
+	connections := make([][]int, holes)
+	added := map[[2]int]bool{}
+	for _, tunnel := range tunnels {
+		if tunnel[0] >= holes || tunnel[1] >= holes {
+			return nil, fmt.Errorf("tunnel %d -> %d uses a hole past the %d holes", tunnel[0], tunnel[1], holes)
+		}
+		if !added[tunnel] {
+			added[tunnel] = true
+			connections[tunnel[0]] = append(connections[tunnel[0]], tunnel[1])
+		}
+	}
+	for i := range connections {
+		if connections[i] == nil {
+			connections[i] = []int{}
+		}
+	}
+
+	definition := &GridDefinition{
+		Connections: connections,
+	}
+	definition.Directed = !definition.IsSymmetric()
+	definition.Symmetries = definition.FindAutomorphisms()
+
+	return definition, definition.Validate()
+
+}
+
+/*
+	Determines if every connection can also be used in the
+	other direction.
+*/
+func (d *GridDefinition) IsSymmetric() bool {
+
+	connected, _ := connectionSets(d)
+	for i, connections := range d.Connections {
+		for _, j := range connections {
+			if !connected[j][i] {
+				return false
+			}
+		}
+	}
+
+	return true
+
+}
+
+/*
+	Checks that a grid definition is well formed. Connections
+	have to point at real cells and can't be repeated, grids which
+	aren't directed need every connection in both directions, and
+	every symmetry has to be an automorphism of the connections.
+*/
+func (d *GridDefinition) Validate() error {
+
+	n := len(d.Connections)
+	for i, connections := range d.Connections {
+		seen := map[int]bool{}
+		for _, j := range connections {
+			if j < 0 || j >= n {
+				return fmt.Errorf("cell %d is connected to %d which isn't a cell", i, j)
+			}
+			if seen[j] {
+				return fmt.Errorf("cell %d is connected to %d more than once", i, j)
+			}
+			seen[j] = true
+		}
+	}
+
+	if !d.Directed && !d.IsSymmetric() {
+		return fmt.Errorf("grid isn't directed but has one way connections")
+	}
+
+	if len(d.Symmetries) == 0 {
+		return fmt.Errorf("grid needs at least one symmetry (the identity)")
+	}
+	for i, symmetry := range d.Symmetries {
+		if !d.IsSymmetry(symmetry) {
+			return fmt.Errorf("symmetry %d isn't an automorphism of the connections", i)
+		}
+	}
+
+	if d.Coordinates != nil && len(d.Coordinates) != n {
+		return fmt.Errorf("grid has %d cells but %d coordinates", n, len(d.Coordinates))
+	}
+
+	return nil
+
+}
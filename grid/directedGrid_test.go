@@ -0,0 +1,179 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseGridDefinition(t *testing.T) {
+
+	d := parseBoard(t, `
+		holes 5   # the last hole has no tunnels
+		0 -> 1
+		1 -> 2
+		2 -> 0
+		0 - 3
+		0 - 3     # repeated tunnels are ignored
+	`)
+
+	want := [][]int{{1, 3}, {2}, {0}, {0}, {}}
+	if !reflect.DeepEqual(d.Connections, want) {
+		t.Errorf("got connections %v, want %v", d.Connections, want)
+	}
+	if !d.Directed {
+		t.Errorf("board with one way tunnels isn't directed")
+	}
+
+	// Only the identity keeps the tail on 0 and the loop going the same way
+	checkDefinition(t, d, 5, 1)
+
+	// Without a count the holes stop at the largest one used
+	d = parseBoard(t, "0 - 1\n1 - 2")
+	if len(d.Connections) != 3 || d.Directed {
+		t.Errorf("got %d holes and directed %v, want 3 holes and undirected", len(d.Connections), d.Directed)
+	}
+
+}
+
+func TestParseGridDefinitionErrors(t *testing.T) {
+
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"unknown arrow", "0 => 1"},
+		{"missing hole", "0 -"},
+		{"negative hole", "0 - -1"},
+		{"bad count", "holes x"},
+		{"hole past the count", "holes 2\n0 - 3"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := ParseGridDefinition(test.text); err == nil {
+				t.Errorf("expected an error for %q", test.text)
+			}
+		})
+	}
+
+}
+
+func TestLoadGridDefinition(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "board.txt")
+	if err := ioutil.WriteFile(path, []byte("0 - 1\n1 - 2\n2 - 3\n3 - 0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d, err := LoadGridDefinition(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkDefinition(t, d, 4, 8)
+
+	if _, err := LoadGridDefinition(filepath.Join(t.TempDir(), "missing.txt")); err == nil {
+		t.Errorf("expected an error for a missing file")
+	}
+
+}
+
+func TestAutomorphisms(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		board *GridDefinition
+		want  int
+	}{
+		{"cycle", CreateCycleGrid(5), 10},
+
+		// Only the rotations respect the direction of the tunnels
+		{"directed cycle", CreateDirectedCycleGrid(5), 5},
+		{"path", CreateLinearGrid(4), 2},
+
+		// Every ordering of holes with no tunnels at all
+		{"no tunnels", &GridDefinition{Connections: [][]int{{}, {}, {}}}, 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			automorphisms := test.board.FindAutomorphisms()
+			if len(automorphisms) != test.want {
+				t.Errorf("got %d automorphisms, want %d", len(automorphisms), test.want)
+			}
+			for _, automorphism := range automorphisms {
+				if !test.board.IsSymmetry(automorphism) {
+					t.Errorf("%v isn't a symmetry", automorphism)
+				}
+			}
+		})
+	}
+
+}
+
+func TestFindIsomorphism(t *testing.T) {
+
+	// A square drawn with its holes in a different order
+	square := parseBoard(t, "0 - 2\n2 - 1\n1 - 3\n3 - 0")
+	isomorphism := FindIsomorphism(CreateCycleGrid(4), square)
+	if isomorphism == nil {
+		t.Fatal("no isomorphism between two squares")
+	}
+	for i, connections := range CreateCycleGrid(4).Connections {
+		for _, j := range connections {
+			if !containsCell(square.Connections[isomorphism[i]], isomorphism[j]) {
+				t.Errorf("tunnel %d - %d isn't mapped onto a tunnel", i, j)
+			}
+		}
+	}
+
+	if FindIsomorphism(CreateCycleGrid(4), CreateLinearGrid(4)) != nil {
+		t.Errorf("found an isomorphism between a cycle and a path")
+	}
+	if FindIsomorphism(CreateCycleGrid(4), CreateDirectedCycleGrid(4)) != nil {
+		t.Errorf("found an isomorphism between a cycle and a directed cycle")
+	}
+
+}
+
+func containsCell(cells []int, cell int) bool {
+	for _, c := range cells {
+		if c == cell {
+			return true
+		}
+	}
+	return false
+}
+
+func TestValidate(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		board *GridDefinition
+	}{
+		{"missing cell", &GridDefinition{Connections: [][]int{{1}, {2}}, Symmetries: [][]int{{0, 1}}}},
+		{"repeated connection", &GridDefinition{Connections: [][]int{{1, 1}, {0, 0}}, Symmetries: [][]int{{0, 1}}}},
+		{"one way", &GridDefinition{Connections: [][]int{{1}, {}}, Symmetries: [][]int{{0, 1}}}},
+		{"no symmetries", &GridDefinition{Connections: [][]int{{1}, {0}}}},
+		{"bad symmetry", &GridDefinition{Connections: [][]int{{1}, {0, 2}, {1}}, Symmetries: [][]int{{0, 1, 2}, {1, 0, 2}}}},
+		{"missing coordinates", &GridDefinition{Connections: [][]int{{1}, {0}}, Symmetries: [][]int{{0, 1}}, Coordinates: [][]int{{0}}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if err := test.board.Validate(); err == nil {
+				t.Errorf("expected an error")
+			}
+		})
+	}
+
+	// The same one way tunnel is fine once the board is directed
+	directed := &GridDefinition{Connections: [][]int{{1}, {}}, Symmetries: [][]int{{0, 1}}, Directed: true}
+	if err := directed.Validate(); err != nil {
+		t.Errorf("directed board failed validation: %v", err)
+	}
+
+}
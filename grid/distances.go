@@ -0,0 +1,76 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"sync"
+)
+
+/*
+	Cache of the distances between every pair of cells
+*/
+type distancesCache struct {
+	once      sync.Once
+	distances [][]int
+}
+
+/*
+	Returns the number of connections the fox needs to follow to
+	get from each cell to each other cell. Cells which can't be
+	reached have a distance of -1. The result is cached, so the
+	connections shouldn't be changed after this is called.
+*/
+func (d *GridDefinition) Distances() [][]int {
+
+	d.distancesCache.once.Do(func() {
+
+		// Breadth first search from every cell
+		distances := [][]int{}
+		for start := range d.Connections {
+			cellDistances := make([]int, len(d.Connections))
+			for i := range cellDistances {
+				cellDistances[i] = -1
+			}
+			cellDistances[start] = 0
+
+			queue := []int{start}
+			for len(queue) > 0 {
+				cell := queue[0]
+				queue = queue[1:]
+				for _, j := range d.Connections[cell] {
+					if cellDistances[j] == -1 {
+						cellDistances[j] = cellDistances[cell] + 1
+						queue = append(queue, j)
+					}
+				}
+			}
+
+			distances = append(distances, cellDistances)
+		}
+
+		d.distancesCache.distances = distances
+
+	})
+
+	return d.distancesCache.distances
+
+}
+
+/*
+	Returns every cell within the given distance of any of the cells
+*/
+func (d *GridDefinition) WithinDistance(cells map[int]bool, radius int) map[int]bool {
+
+	distances := d.Distances()
+	within := map[int]bool{}
+	for cell := range cells {
+		for j, distance := range distances[cell] {
+			if distance != -1 && distance <= radius {
+				within[j] = true
+			}
+		}
+	}
+
+	return within
+
+}
@@ -0,0 +1,145 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+/*
+	Belief about a fox which moves at random rather than trying
+	to escape. Each value is the probability the fox is in that
+	hole and hasn't been caught yet, so the total is the chance
+	the fox is still free.
+*/
+type Distribution struct {
+	Probabilities []float64
+
+	// Number of nights the fox has moved through
+	Day int
+}
+
+/*
+	How reliable checks are. A check that misses leaves the fox
+	where it is, so a checked hole is only down-weighted.
+*/
+type Reliability struct {
+
+	// Chance that a check misses a fox in the checked hole
+	Miss float64
+
+	// Miss chances for particular holes, used instead of Miss
+	HoleMiss map[int]float64
+}
+
+/*
+	Returns the chance that a check of the given hole misses the fox
+*/
+func (reliability Reliability) MissProbability(hole int) float64 {
+	if miss, exists := reliability.HoleMiss[hole]; exists {
+		return miss
+	}
+	return reliability.Miss
+}
+
+/*
+	The check reliability used when propogating distributions.
+	By default checks never miss.
+*/
+var CheckReliability = Reliability{}
+
+/*
+	Creates a distribution where the fox is equally likely to be
+	in any of the shaded cells of the grid.
+*/
+func CreateUniformDistribution(grid *Grid) *Distribution {
+
+	probabilities := make([]float64, len(grid.Values))
+	n := grid.NFoxes()
+	for i, value := range grid.Values {
+		if value {
+			probabilities[i] = 1 / float64(n)
+		}
+	}
+
+	return &Distribution{
+		Probabilities: probabilities,
+		Day:           grid.Day,
+	}
+
+}
+
+/*
+	The probability the fox hasn't been caught yet
+*/
+func (distribution *Distribution) Remaining() float64 {
+	total := 0.0
+	for _, p := range distribution.Probabilities {
+		total += p
+	}
+	return total
+}
+
+/*
+	Checks the given holes and then moves the fox overnight,
+	choosing uniformly at random between every move it can make
+	under the current FoxRules. A fox in a sink stays where it is
+	(see MovesOn). Checks miss according to CheckReliability. Returns the new
+	distribution and the probability that the fox was caught by the
+	checks.
+*/
+func (distribution *Distribution) PropogateWithChecks(checks map[int]bool) (*Distribution, float64) {
+
+	moves := BaseGrid.MovesOn(distribution.Day)
+	probabilities := make([]float64, len(distribution.Probabilities))
+	caught := 0.0
+
+	for i, p := range distribution.Probabilities {
+		if p == 0 {
+			continue
+		}
+		if checks[i] {
+			miss := CheckReliability.MissProbability(i)
+			caught += p * (1 - miss)
+			p *= miss
+			if p == 0 {
+				continue
+			}
+		}
+		share := p / float64(len(moves[i]))
+		for _, j := range moves[i] {
+			probabilities[j] += share
+		}
+	}
+
+	return &Distribution{Probabilities: probabilities, Day: distribution.Day + 1}, caught
+
+}
+
+/*
+	Returns where the fox is given that it hasn't been caught yet.
+	This is the bayesian update for every check that came up empty,
+	so the probabilities add up to 1 (unless the fox must have been
+	caught, in which case they're all 0).
+*/
+func (distribution *Distribution) Posterior() *Distribution {
+
+	remaining := distribution.Remaining()
+	probabilities := make([]float64, len(distribution.Probabilities))
+	if remaining > 0 {
+		for i, p := range distribution.Probabilities {
+			probabilities[i] = p / remaining
+		}
+	}
+
+	return &Distribution{Probabilities: probabilities, Day: distribution.Day}
+
+}
+
+/*
+	Returns a grid with every hole the fox could be in shaded
+*/
+func (distribution *Distribution) Grid() *Grid {
+	grid := CreateBlankGrid()
+	grid.Day = distribution.Day
+	for i, p := range distribution.Probabilities {
+		grid.Values[i] = p > 0
+	}
+	return grid
+}
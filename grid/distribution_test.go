@@ -0,0 +1,67 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"math"
+	"testing"
+)
+
+func closeTo(a, b float64) bool {
+	return math.Abs(a - b) < 1e-9
+}
+
+func TestUnreliableChecks(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		reliability Reliability
+		caught      float64
+	}{
+		{"reliable", Reliability{}, 1.0 / 3},
+		{"misses", Reliability{Miss: 0.2}, 0.8 / 3},
+
+		// The middle hole has its own chance of missing
+		{"hole misses", Reliability{Miss: 0.2, HoleMiss: map[int]float64{1: 0.5}}, 0.5 / 3},
+		{"never finds", Reliability{Miss: 1}, 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, CreateLinearGrid(3))
+			CheckReliability = test.reliability
+
+			start := CreateUniformDistribution(CreateFullGrid())
+			next, caught := start.PropogateWithChecks(map[int]bool{1: true})
+			if !closeTo(caught, test.caught) {
+				t.Errorf("caught %v, want %v", caught, test.caught)
+			}
+
+			// Whatever isn't caught is still somewhere on the board
+			if !closeTo(next.Remaining(), 1 - test.caught) {
+				t.Errorf("%v remaining, want %v", next.Remaining(), 1 - test.caught)
+			}
+			if !closeTo(next.Posterior().Remaining(), 1) {
+				t.Errorf("posterior adds up to %v, want 1", next.Posterior().Remaining())
+			}
+		})
+	}
+
+}
+
+func TestPosteriorWhenCaught(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(3))
+
+	start := CreateUniformDistribution(CreateFullGrid())
+	next, caught := start.PropogateWithChecks(map[int]bool{0: true, 1: true, 2: true})
+	if !closeTo(caught, 1) {
+		t.Errorf("caught %v checking every hole, want 1", caught)
+	}
+	for hole, p := range next.Posterior().Probabilities {
+		if p != 0 {
+			t.Errorf("fox that must have been caught is in hole %d with probability %v", hole, p)
+		}
+	}
+
+}
@@ -0,0 +1,157 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"sync"
+)
+
+/*
+	Cache of the tunnels of a grid definition
+*/
+type edgesCache struct {
+	once    sync.Once
+	edges   [][2]int
+	indexes map[[2]int]int
+}
+
+/*
+	Returns every tunnel of the grid. For grids which aren't
+	directed a tunnel can be used both ways, so it's only listed
+	once (with the lower cell first). For directed grids every one
+	way connection is its own tunnel. Grids with a schedule include
+	every tunnel which is open on any day. The index of a tunnel in
+	this list is used to watch it. Like Distances, the result is
+	cached.
+*/
+func (d *GridDefinition) Edges() [][2]int {
+	d.buildEdges()
+	return d.edgesCache.edges
+}
+
+/*
+	Returns the index of the tunnel the fox uses to get from one
+	cell to another, or -1 if there isn't one.
+*/
+func (d *GridDefinition) EdgeIndex(from, to int) int {
+	d.buildEdges()
+	if !d.Directed && to < from {
+		from, to = to, from
+	}
+	if index, exists := d.edgesCache.indexes[[2]int{from, to}]; exists {
+		return index
+	}
+	return -1
+}
+
+/*
+	Builds the tunnels from the connections the first time they're needed
+*/
+func (d *GridDefinition) buildEdges() {
+	d.edgesCache.once.Do(func() {
+
+		edges := [][2]int{}
+		indexes := map[[2]int]int{}
+		for _, dayConnections := range append([][][]int{d.Connections}, d.Schedule...) {
+			for from, connections := range dayConnections {
+				for _, to := range connections {
+					edge := [2]int{from, to}
+					if !d.Directed && to < from {
+						edge = [2]int{to, from}
+					}
+					if _, exists := indexes[edge]; !exists {
+						indexes[edge] = len(edges)
+						edges = append(edges, edge)
+					}
+				}
+			}
+		}
+
+		d.edgesCache.edges = edges
+		d.edgesCache.indexes = indexes
+
+	})
+}
+
+/*
+	Checks the given holes and watches the given tunnels (by index
+	in Edges), then moves the fox overnight. A fox is caught if it's
+	in a checked hole or if it goes through a watched tunnel, so it
+	only ends up where it can get to without using one. Exits work
+	the same way as in PropgateWithChecks.
+*/
+func (grid *Grid) PropogateWithEdgeChecks(holes map[int]bool, edges map[int]bool) *Grid {
+
+	newGrid := CreateBlankGrid()
+	newGrid.Day = grid.Day + 1
+	newGrid.Escaped = grid.Escaped
+	newGrid.Checks = append([]map[int]bool{}, grid.Checks...)
+	newGrid.EdgeChecks = append([]map[int]bool{}, grid.EdgeChecks...)
+
+	connections := BaseGrid.ConnectionsOn(grid.Day)
+	steps := FoxRules.Steps
+	if steps < 1 {
+		steps = 1
+	}
+
+	for cell, value := range grid.Values {
+
+		if !value || holes[cell] {
+			continue
+		}
+
+		// A fox left in an exit hole gets away overnight
+		if BaseGrid.IsExit(cell) {
+			newGrid.Escaped = true
+		}
+
+		// A fox in a sink can't go anywhere, same as in MovesOn
+		if FoxRules.Stay || len(connections[cell]) == 0 {
+			newGrid.Values[cell] = true
+		}
+
+		// Walk outwards one step at a time, avoiding the watched tunnels
+		frontier := map[int]bool{cell: true}
+		for step := 0; step < steps; step++ {
+			newFrontier := map[int]bool{}
+			for i := range frontier {
+				for _, j := range connections[i] {
+					if index := BaseGrid.EdgeIndex(i, j); index == -1 || !edges[index] {
+						newFrontier[j] = true
+						newGrid.Values[j] = true
+					}
+				}
+			}
+			frontier = newFrontier
+		}
+
+	}
+
+	return newGrid
+
+}
+
+/*
+	Returns the tunnels which are worth watching: every open
+	tunnel the fox could use tonight.
+*/
+func (grid *Grid) UsefulEdges() []int {
+
+	useful := map[int]bool{}
+	edges := []int{}
+	for from, connections := range BaseGrid.ConnectionsOn(grid.Day) {
+		if !grid.Values[from] {
+			continue
+		}
+		for _, to := range connections {
+			index := BaseGrid.EdgeIndex(from, to)
+			if index != -1 && !useful[index] {
+				useful[index] = true
+				edges = append(edges, index)
+			}
+		}
+	}
+
+	return edges
+
+}
@@ -0,0 +1,44 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"testing"
+)
+
+func TestScheduledEdges(t *testing.T) {
+
+	// A path of 3, with a tunnel straight from 0 to 2 that only exists in the schedule
+	base := CreateLinearGrid(3)
+	d := CreateScheduledGrid(base, [][][]int{{{1, 2}, {0, 2}, {1, 0}}}, true)
+	useBoard(t, d)
+
+	if len(d.Edges()) != 3 {
+		t.Fatalf("got %d tunnels %v, want 3", len(d.Edges()), d.Edges())
+	}
+	shortcut := d.EdgeIndex(2, 0)
+	if shortcut == -1 {
+		t.Fatal("the scheduled tunnel from 0 to 2 has no index")
+	}
+
+	for _, index := range CreateFullGrid().UsefulEdges() {
+		if index == -1 {
+			t.Error("a tunnel without an index was worth watching")
+		}
+	}
+
+	// Watching 0 to 1 leaves the fox in 0 free to take the shortcut
+	start := CreateBlankGrid()
+	start.Values[0] = true
+	newGrid := start.PropogateWithEdgeChecks(map[int]bool{}, map[int]bool{d.EdgeIndex(0, 1): true})
+	if newGrid.Values[1] || !newGrid.Values[2] {
+		t.Errorf("got %v, want the fox to only be in 2", newGrid.Values)
+	}
+
+	// Watching both gets it
+	newGrid = start.PropogateWithEdgeChecks(map[int]bool{}, map[int]bool{d.EdgeIndex(0, 1): true, shortcut: true})
+	if newGrid.NFoxes() != 0 {
+		t.Errorf("got %v, want the fox caught", newGrid.Values)
+	}
+
+}
@@ -0,0 +1,166 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+/*
+	Recognizes grid definitions which are a single line of holes
+	(like those from CreateLinearGrid). Returns the holes in order
+	from one end to the other.
+*/
+func (d *GridDefinition) PathOrder() ([]int, bool) {
+
+	n := len(d.Connections)
+	if n == 0 || d.Directed || !d.IsStronglyConnected() || countTunnels(d) != n-1 {
+		return nil, false
+	}
+
+	// Start from an end, which only has one neighbor
+	start := 0
+	for cell, node := range d.Connections {
+		if len(node) > 2 {
+			return nil, false
+		}
+		if len(node) <= 1 {
+			start = cell
+		}
+	}
+
+	return walk(d, start), true
+
+}
+
+/*
+	Recognizes grid definitions which are a single loop of holes
+	(like those from CreateCycleGrid). Returns the holes in order
+	around the loop, starting from hole 0.
+*/
+func (d *GridDefinition) CycleOrder() ([]int, bool) {
+
+	n := len(d.Connections)
+	if n < 3 || d.Directed || !d.IsStronglyConnected() {
+		return nil, false
+	}
+	for _, node := range d.Connections {
+		if len(node) != 2 {
+			return nil, false
+		}
+	}
+
+	return walk(d, 0), true
+
+}
+
+/*
+	Recognizes grid definitions which are 2 rows of holes side by
+	side (a prism grid with lengths n and 2). Returns each row in
+	order, lined up so that rows[0][i] is next to rows[1][i].
+*/
+func (d *GridDefinition) LadderOrder() ([2][]int, bool) {
+
+	var rows [2][]int
+	n := len(d.Connections)
+	if n < 4 || d.Directed || len(d.Coordinates) != n || len(d.Coordinates[0]) != 2 {
+		return rows, false
+	}
+
+	lengths := []int{0, 0}
+	for _, coordinate := range d.Coordinates {
+		for axis, x := range coordinate {
+			if x < 0 {
+				return rows, false
+			}
+			if x+1 > lengths[axis] {
+				lengths[axis] = x + 1
+			}
+		}
+	}
+
+	// The rows run along the long axis
+	long := 0
+	if lengths[0] == 2 {
+		long = 1
+	}
+	if lengths[1 - long] != 2 || lengths[long] * 2 != n {
+		return rows, false
+	}
+
+	// Has to match the prism grid with the same coordinates exactly
+	ladder := CreatePrismGrid(lengths)
+	dimensionSizes, _ := getDimensionSizes(lengths)
+	indexes := make([]int, n)
+	cells := map[int]int{}
+	for cell, coordinate := range d.Coordinates {
+		indexes[cell] = getIndex(dimensionSizes, coordinate)
+		cells[indexes[cell]] = cell
+	}
+	if len(cells) != n || !sameConnections(d.Connections, ladder.Connections, indexes) {
+		return rows, false
+	}
+
+	for y := 0; y < 2; y++ {
+		for x := 0; x < lengths[long]; x++ {
+			location := make([]int, 2)
+			location[long], location[1 - long] = x, y
+			rows[y] = append(rows[y], cells[getIndex(dimensionSizes, location)])
+		}
+	}
+
+	return rows, true
+
+}
+
+// Number of tunnels in a grid which isn't directed
+func countTunnels(d *GridDefinition) int {
+	total := 0
+	for _, node := range d.Connections {
+		total += len(node)
+	}
+	return total / 2
+}
+
+// Follows a path or cycle from a cell until it runs out of new holes
+func walk(d *GridDefinition, start int) []int {
+
+	order := []int{start}
+	visited := map[int]bool{start: true}
+	for {
+		next := -1
+		for _, j := range d.Connections[order[len(order)-1]] {
+			if !visited[j] {
+				next = j
+				break
+			}
+		}
+		if next == -1 {
+			return order
+		}
+		visited[next] = true
+		order = append(order, next)
+	}
+
+}
+
+/*
+	Whether or not a maps onto b exactly when each cell i of a is
+	renamed to indexes[i]
+*/
+func sameConnections(a, b [][]int, indexes []int) bool {
+	for i, node := range a {
+		if len(node) != len(b[indexes[i]]) {
+			return false
+		}
+		for _, j := range node {
+			found := false
+			for _, k := range b[indexes[i]] {
+				if k == indexes[j] {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return false
+			}
+		}
+	}
+	return true
+}
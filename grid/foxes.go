@@ -0,0 +1,186 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+/*
+	Belief state for hunting several indistinguishable foxes at
+	once. Each configuration is one possible arrangement of the
+	foxes which haven't been caught yet, given as the sorted holes
+	they're in. Every fox moves independently each night.
+
+	The hunter isn't told when a fox is caught, so a strategy has
+	to catch every fox in every configuration to win.
+*/
+type Foxes struct {
+	Configurations [][]int
+
+	// Whether or not two foxes can share a hole
+	Distinct bool
+
+	// Number of nights the foxes have moved through
+	Day int
+}
+
+/*
+	Creates the belief where each of the n foxes could be in any
+	of the shaded cells of the grid.
+*/
+func CreateFoxes(grid *Grid, n int, distinct bool) *Foxes {
+
+	cells := []int{}
+	for i, value := range grid.Values {
+		if value {
+			cells = append(cells, i)
+		}
+	}
+
+	// Build up the configurations one fox at a time, keeping them sorted
+	configurations := [][]int{{}}
+	for fox := 0; fox < n; fox++ {
+		newConfigurations := [][]int{}
+		for _, configuration := range configurations {
+			for _, cell := range cells {
+				if len(configuration) > 0 {
+					last := configuration[len(configuration)-1]
+					if cell < last || (distinct && cell == last) {
+						continue
+					}
+				}
+				newConfiguration := append(append([]int{}, configuration...), cell)
+				newConfigurations = append(newConfigurations, newConfiguration)
+			}
+		}
+		configurations = newConfigurations
+	}
+
+	return &Foxes{
+		Configurations: configurations,
+		Distinct:       distinct,
+		Day:            grid.Day,
+	}
+
+}
+
+/*
+	Checks the given holes, removing any fox found in them, and
+	then moves every remaining fox overnight.
+*/
+func (foxes *Foxes) PropogateWithChecks(checks map[int]bool) *Foxes {
+
+	moves := BaseGrid.MovesOn(foxes.Day)
+	seen := map[string]bool{}
+	newConfigurations := [][]int{}
+
+	for _, configuration := range foxes.Configurations {
+
+		// Any fox in a checked hole is caught
+		remaining := []int{}
+		for _, cell := range configuration {
+			if !checks[cell] {
+				remaining = append(remaining, cell)
+			}
+		}
+
+		// Every combination of moves for the remaining foxes
+		moved := [][]int{{}}
+		for _, cell := range remaining {
+			newMoved := [][]int{}
+			for _, partial := range moved {
+				for _, j := range moves[cell] {
+					newMoved = append(newMoved, append(append([]int{}, partial...), j))
+				}
+			}
+			moved = newMoved
+		}
+
+	movedLoop:
+		for _, newConfiguration := range moved {
+			sort.Ints(newConfiguration)
+			if foxes.Distinct {
+				for i := 1; i < len(newConfiguration); i++ {
+					if newConfiguration[i] == newConfiguration[i-1] {
+						continue movedLoop
+					}
+				}
+			}
+
+			key := hashSymmetry(newConfiguration)
+			if !seen[key] {
+				seen[key] = true
+				newConfigurations = append(newConfigurations, newConfiguration)
+			}
+		}
+
+	}
+
+	return &Foxes{
+		Configurations: newConfigurations,
+		Distinct:       foxes.Distinct,
+		Day:            foxes.Day + 1,
+	}
+
+}
+
+/*
+	Whether or not every fox has been caught in every configuration
+*/
+func (foxes *Foxes) Caught() bool {
+	for _, configuration := range foxes.Configurations {
+		if len(configuration) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+/*
+	Returns a grid with every hole that could have a fox in it shaded
+*/
+func (foxes *Foxes) Grid() *Grid {
+	grid := CreateBlankGrid()
+	grid.Day = foxes.Day
+	for _, configuration := range foxes.Configurations {
+		for _, cell := range configuration {
+			grid.Values[cell] = true
+		}
+	}
+	return grid
+}
+
+/*
+	Create a hash for the belief. Beliefs which are symmetric to
+	each other under BaseGrid.Symmetries have the same hash. The
+	phase of the BaseGrid schedule is part of the hash.
+*/
+func (foxes *Foxes) Hash() string {
+
+	lowestHash := ""
+	for i, symmetry := range BaseGrid.Symmetries {
+
+		configurationHashes := []string{}
+		for _, configuration := range foxes.Configurations {
+			mapped := []int{}
+			for _, cell := range configuration {
+				mapped = append(mapped, symmetry[cell])
+			}
+			sort.Ints(mapped)
+			configurationHashes = append(configurationHashes, hashSymmetry(mapped))
+		}
+		sort.Strings(configurationHashes)
+
+		hash := strings.Join(configurationHashes, "|")
+		if i == 0 || hash < lowestHash {
+			lowestHash = hash
+		}
+
+	}
+
+	return fmt.Sprint(BaseGrid.Phase(foxes.Day), ":", lowestHash)
+
+}
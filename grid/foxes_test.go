@@ -0,0 +1,38 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"testing"
+)
+
+func TestFoxes(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(5))
+
+	// Unordered pairs of the 5 holes, with or without both in the same hole
+	if got := len(CreateFoxes(CreateFullGrid(), 2, true).Configurations); got != 10 {
+		t.Errorf("got %d configurations of distinct foxes, want 10", got)
+	}
+	if got := len(CreateFoxes(CreateFullGrid(), 2, false).Configurations); got != 15 {
+		t.Errorf("got %d configurations of foxes sharing holes, want 15", got)
+	}
+
+	// A fox in 0 has to move to 1, where it's caught along with the fox already there
+	foxes := &Foxes{Configurations: [][]int{{0, 1}}}
+	next := foxes.PropogateWithChecks(map[int]bool{1: true})
+	if next.Caught() {
+		t.Errorf("fox that moved into the checked hole was caught before it got there")
+	}
+	if next = next.PropogateWithChecks(map[int]bool{1: true}); !next.Caught() {
+		t.Errorf("got configurations %v, want every fox caught", next.Configurations)
+	}
+
+	// Reflecting the board doesn't change the belief
+	left := &Foxes{Configurations: [][]int{{0, 1}}}
+	right := &Foxes{Configurations: [][]int{{3, 4}}}
+	if left.Hash() != right.Hash() {
+		t.Errorf("mirrored beliefs have different hashes")
+	}
+
+}
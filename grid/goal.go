@@ -0,0 +1,78 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+/*
+	When the hunt is over. By default that's once the fox has
+	been caught, but sometimes it's enough to know roughly where
+	the fox is.
+*/
+type Goal struct {
+
+	// Stop once the fox can only be in this many holes. 0 means it has to be caught.
+	MaxHoles int
+
+	/*
+		Stop once every hole the fox could be in is within this
+		distance of a single hole. 0 means there's no radius goal.
+	*/
+	Radius int
+}
+
+/*
+	The goal used by every solver which hunts a single fox. By
+	default the fox has to be caught.
+*/
+var HuntGoal = Goal{}
+
+/*
+	Whether or not the goal is to catch the fox
+*/
+func (goal Goal) IsCapture() bool {
+	return goal.MaxHoles <= 0 && goal.Radius <= 0
+}
+
+/*
+	Whether or not the hunter has done enough with the grid
+*/
+func (goal Goal) Reached(grid *Grid) bool {
+
+	nFoxes := grid.NFoxes()
+	if nFoxes == 0 || nFoxes <= goal.MaxHoles {
+		return true
+	}
+
+	if goal.Radius > 0 {
+		_, found := grid.Center(goal.Radius)
+		return found
+	}
+
+	return false
+
+}
+
+/*
+	Finds a hole which every hole the fox could be in is within the
+	radius of. Returns false if there isn't one.
+*/
+func (grid *Grid) Center(radius int) (int, bool) {
+
+	distances := BaseGrid.Distances()
+
+centerLoop:
+	for center := range grid.Values {
+		for hole, value := range grid.Values {
+			if !value {
+				continue
+			}
+			distance := pairDistance(distances, center, hole)
+			if distance == -1 || distance > radius {
+				continue centerLoop
+			}
+		}
+		return center, true
+	}
+
+	return -1, false
+
+}
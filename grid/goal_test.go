@@ -0,0 +1,68 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"testing"
+)
+
+func TestGoalReached(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(7))
+
+	tests := []struct {
+		name  string
+		goal  Goal
+		holes string
+		want  bool
+	}{
+		{"caught", Goal{}, "", true},
+		{"not caught", Goal{}, "3", false},
+		{"few enough holes", Goal{MaxHoles: 2}, "0,6", true},
+		{"too many holes", Goal{MaxHoles: 2}, "0,3,6", false},
+
+		// 2 and 4 are both next to 3
+		{"close together", Goal{Radius: 1}, "2,4", true},
+		{"too far apart", Goal{Radius: 1}, "2,5", false},
+		{"either goal", Goal{MaxHoles: 1, Radius: 1}, "1,2,3", true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			g := CreateBlankGrid()
+			if test.holes != "" {
+				var err error
+				if g, err = ParseGrid(test.holes); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := test.goal.Reached(g); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+
+	if !(Goal{}).IsCapture() || (Goal{MaxHoles: 1}).IsCapture() || (Goal{Radius: 1}).IsCapture() {
+		t.Error("only the default goal should be catching the fox")
+	}
+
+}
+
+func TestCenter(t *testing.T) {
+
+	useBoard(t, parseBoard(t, "0 -> 1\n1 -> 2\n2 - 3"))
+
+	g, err := ParseGrid("0,2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Distances go either way, so 1 is next to 0 even though the fox can only go from 0 to 1
+	if center, found := g.Center(1); !found || center != 1 {
+		t.Errorf("got center %d (found %v), want 1", center, found)
+	}
+	if _, found := g.Center(0); found {
+		t.Error("found a center for two holes with a radius of 0")
+	}
+
+}
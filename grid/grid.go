@@ -2,6 +2,11 @@
 
 package grid
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
 
 /*
 	A grid is an array of bools which indicate whether or
@@ -10,6 +15,18 @@ package grid
 type Grid struct {
 	Values []bool
 	Checks []map[int]bool
+
+	// Number of nights the grid has been propogated through
+	Day int
+
+	/*
+		Whether or not the fox could have escaped through one of
+		the BaseGrid exits. Once true, the hunter has lost.
+	*/
+	Escaped bool
+
+	// Tunnels watched each day, when watching tunnels (see edges.go)
+	EdgeChecks []map[int]bool
 }
 
 /*
@@ -25,6 +42,47 @@ func CreateBlankGrid() *Grid {
 
 }
 
+/*
+	Function for generating a grid where the fox could
+	be in any of the holes.
+*/
+func CreateFullGrid() *Grid {
+
+	grid := CreateBlankGrid()
+	for i := range grid.Values {
+		grid.Values[i] = true
+	}
+	return grid
+
+}
+
+/*
+	Parses a set of holes the fox could start in. Either "all" for
+	the full board, or the holes separated by commas (like "0,3,5").
+*/
+func ParseGrid(text string) (*Grid, error) {
+
+	text = strings.TrimSpace(text)
+	if text == "all" {
+		return CreateFullGrid(), nil
+	}
+
+	grid := CreateBlankGrid()
+	for _, field := range strings.Split(text, ",") {
+		hole, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil {
+			return nil, fmt.Errorf("invalid hole %q", strings.TrimSpace(field))
+		}
+		if hole < 0 || hole >= len(grid.Values) {
+			return nil, fmt.Errorf("hole %d isn't one of the %d holes", hole, len(grid.Values))
+		}
+		grid.Values[hole] = true
+	}
+
+	return grid, nil
+
+}
+
 /*
 	Function for copying a grid
 */
@@ -39,6 +97,9 @@ func (grid *Grid) Copy() *Grid {
 		newChecks[i] = value
 	}
 	newGrid.Checks = newChecks
+	newGrid.Day = grid.Day
+	newGrid.Escaped = grid.Escaped
+	newGrid.EdgeChecks = append([]map[int]bool{}, grid.EdgeChecks...)
 
 	return newGrid
 
@@ -50,8 +111,10 @@ func (grid *Grid) Copy() *Grid {
 func (grid *Grid) Propogate() *Grid {
 
 	newGrid := CreateBlankGrid()
+	newGrid.Day = grid.Day + 1
+	moves := BaseGrid.MovesOn(grid.Day)
 	for i, value := range grid.Values {
-		for _, j := range BaseGrid.Connections[i] {
+		for _, j := range moves[i] {
 			newGrid.Values[j] = newGrid.Values[j] || value
 		}
 	}
@@ -79,9 +142,18 @@ func (grid *Grid) AddChecks(checks map[int]bool) {
 func (grid *Grid) PropgateWithChecks(checks map[int]bool) *Grid {
 
 	newGrid := CreateBlankGrid()
+	newGrid.Day = grid.Day + 1
+	newGrid.Escaped = grid.Escaped
+	moves := BaseGrid.MovesOn(grid.Day)
 	for i, value := range grid.Values {
 		checkedValue := checks[i]
-		for _, j := range BaseGrid.Connections[i] {
+
+		// A fox left in an exit hole gets away overnight
+		if value && !checkedValue && BaseGrid.IsExit(i) {
+			newGrid.Escaped = true
+		}
+
+		for _, j := range moves[i] {
 			propogatedValue := !checkedValue && value
 			newGrid.Values[j] = newGrid.Values[j] || propogatedValue
 		}
@@ -109,6 +181,7 @@ func (grid *Grid) HowToRemove(checks map[int]bool) []map[int]bool {
 		howToRemove = append(howToRemove, map[int]bool{})
 	}
 
+	moves := BaseGrid.MovesOn(grid.Day)
 	for i, value := range grid.Values {
 
 		// Don't need to worry if the grid doesn't have this value as a possibility
@@ -116,7 +189,7 @@ func (grid *Grid) HowToRemove(checks map[int]bool) []map[int]bool {
 			continue
 		}
 
-		for _, conn := range BaseGrid.Connections[i] {
+		for _, conn := range moves[i] {
 			howToRemove[conn][i] = true
 		}
 	}
@@ -198,6 +271,29 @@ configurationLoop:
 	return lowestHash
 }
 
+/*
+	Create a hash for the grid in the order of a single symmetry
+	configuration. The lowest of these over every symmetry is Hash.
+*/
+func (grid *Grid) HashWith(configuration []int) int {
+	hash := 0
+	for power, i := range configuration {
+		if grid.Values[i] {
+			hash += POWERS[power]
+		}
+	}
+	return hash
+}
+
+/*
+	Which phase of the BaseGrid schedule the grid is in. Grids
+	in different phases aren't the same state even if their values
+	match, since the tunnels open to the fox are different.
+*/
+func (grid *Grid) Phase() int {
+	return BaseGrid.Phase(grid.Day)
+}
+
 /*
 	Check if a grid is equal to another grid
 */
@@ -14,6 +14,33 @@ func init() {
 	// Original foxhole problem definition
 	// BaseGrid = CreateLinearGrid(5)
 
+	// Wrapped variants
+	// BaseGrid = CreateCycleGrid(8)
+	// BaseGrid = CreateTorusGrid([]int{6, 6}, []bool{true, true})
+
+	// Hexagonal and triangular lattices
+	// BaseGrid = CreateHexagonGrid(3)
+	// BaseGrid = CreateParallelogramHexGrid(5, 5)
+	// BaseGrid = CreateTriangleGrid(6)
+
+	// Other fox movement rules
+	// BaseGrid = CreatePrismGridWithNeighborhood([]int{5, 5}, MooreNeighborhood())
+	// BaseGrid = CreatePrismGridWithNeighborhood([]int{5, 5}, KnightNeighborhood())
+
+	// Products of other definitions
+	// BaseGrid = CartesianProduct(CreateCycleGrid(6), CreateLinearGrid(4))
+
+	// Tunnels which open and close by day
+	// BaseGrid = CreatePeriodicGrid(CreateLinearGrid(5), 2, func(day, from, to int) bool {
+	// 	return day == 0 || from+to != 5
+	// })
+
+	// Exits the fox can escape through
+	// BaseGrid.SetExits(0)
+
+	// A lazy fox which can also stay in its hole (see rules.go)
+	// FoxRules = Rules{Stay: true, Steps: 1}
+
 	BaseGrid = CreatePrismGrid([]int{8, 8})
 
 }
@@ -46,6 +73,52 @@ type GridDefinition struct {
 
 	// List of orderings which are symettric for the definitions
 	Symmetries [][]int
+
+	/*
+		Lattice coordinates of each node. Prism grids use their
+		location along each axis, hexagonal grids use axial
+		coordinates and triangular grids use the three triangle
+		coordinates. May be nil for hand written definitions.
+	*/
+	Coordinates [][]int
+
+	/*
+		Whether or not the connections are one way. Grids that
+		aren't directed list every connection in both directions.
+	*/
+	Directed bool
+
+	/*
+		Connections for each day when tunnels open and close over
+		time. See CreateScheduledGrid. Empty if the connections
+		never change.
+	*/
+	Schedule [][][]int
+
+	// Whether the schedule starts over once it runs out
+	ScheduleRepeats bool
+
+	/*
+		Holes the fox can escape through. A fox which is in an
+		exit hole at the end of a day (because the hole wasn't
+		checked) gets away that night and the hunter loses.
+	*/
+	Exits []int
+
+	/*
+		Cost of checking each hole. Empty if every hole costs 1.
+		See SetCosts.
+	*/
+	Costs []float64
+
+	// Moves for the current fox rules. See Moves.
+	movesCache movesCache
+
+	// Distances between cells. See Distances.
+	distancesCache distancesCache
+
+	// Tunnels between cells. See Edges.
+	edgesCache edgesCache
 }
 
 /*
@@ -55,7 +128,9 @@ func CreateLinearGrid(n int) *GridDefinition {
 
 	// First create the connections
 	connections := [][]int{}
+	coordinates := [][]int{}
 	for i := 0; i < n; i += 1 {
+		coordinates = append(coordinates, []int{i})
 		node := []int{}
 		if i > 0 {
 			node = append(node, i-1)
@@ -77,6 +152,7 @@ func CreateLinearGrid(n int) *GridDefinition {
 	return &GridDefinition{
 		Connections: connections,
 		Symmetries:  symmetries,
+		Coordinates: coordinates,
 	}
 
 }
@@ -115,42 +191,44 @@ func getLocation(dimensionSizes []int, index int) []int {
 	Create n-cube grid.
 */
 func CreatePrismGrid(dimensionLengths []int) *GridDefinition {
+	return CreatePrismGridWithNeighborhood(dimensionLengths, VonNeumannNeighborhood())
+}
+
+/*
+	Create n-cube grid where the fox can move by any of the
+	offsets in the given neighborhood.
+*/
+func CreatePrismGridWithNeighborhood(dimensionLengths []int, neighborhood Neighborhood) *GridDefinition {
 
 	/*
 		Create the size of each dimension.
 		Use this value to index and un-index things.
 	*/
-	totalCells := 1
-	dimensionSizes := []int{}
-	for _, l := range dimensionLengths {
-		dimensionSizes = append(dimensionSizes, totalCells)
-		totalCells *= l
-	}
+	dimensionSizes, totalCells := getDimensionSizes(dimensionLengths)
+	offsets := neighborhood(len(dimensionLengths))
 
 	// Generate the connections array first
 	connections := [][]int{}
+	coordinates := [][]int{}
 	for i := 0; i < totalCells; i++ {
 		location := getLocation(dimensionSizes, i)
+		coordinates = append(coordinates, location)
 
 		// Generate each of the modified locations based on the current location
 		connectionLocations := []int{}
-		for i, x := range location {
-
-			// Move in the negative direction along an axis
-			if x > 0 {
-				newLocation := make([]int, len(location))
-				copy(newLocation, location)
-				newLocation[i] = x - 1
-				connectionLocations = append(connectionLocations, getIndex(dimensionSizes, newLocation))
+	offsetLoop:
+		for _, offset := range offsets {
+
+			// Skip any moves which would leave the grid
+			newLocation := make([]int, len(location))
+			for axis, x := range location {
+				newLocation[axis] = x + offset[axis]
+				if newLocation[axis] < 0 || newLocation[axis] >= dimensionLengths[axis] {
+					continue offsetLoop
+				}
 			}
 
-			// Move in the positive direction along an axis
-			if x < dimensionLengths[i]-1 {
-				newLocation := make([]int, len(location))
-				copy(newLocation, location)
-				newLocation[i] = x + 1
-				connectionLocations = append(connectionLocations, getIndex(dimensionSizes, newLocation))
-			}
+			connectionLocations = append(connectionLocations, getIndex(dimensionSizes, newLocation))
 
 		}
 
@@ -159,6 +237,30 @@ func CreatePrismGrid(dimensionLengths []int) *GridDefinition {
 
 	}
 
+	definition := &GridDefinition{
+		Connections: connections,
+		Symmetries:  prismSymmetries(dimensionLengths),
+		Coordinates: coordinates,
+	}
+
+	/*
+		Not every ordering of the prism is valid for every neighborhood
+		(or for prisms with sides of different lengths) so only keep
+		the ones which still hold for the generated connections.
+	*/
+	definition.FilterSymmetries()
+
+	return definition
+}
+
+/*
+	Generates the orderings of a prism from every combination of
+	flipping and reordering its axes.
+*/
+func prismSymmetries(dimensionLengths []int) [][]int {
+
+	dimensionSizes, _ := getDimensionSizes(dimensionLengths)
+
 	// Generate all the symettries. First we need the base symmetry
 	symmetryHashes := map[string]bool{}
 	symmetries := [][]int{}
@@ -180,10 +282,7 @@ func CreatePrismGrid(dimensionLengths []int) *GridDefinition {
 
 	}
 
-	return &GridDefinition{
-		Connections: connections,
-		Symmetries:  symmetries,
-	}
+	return symmetries
 }
 
 
@@ -326,9 +425,88 @@ func hashSymmetry(s []int) string {
 	return hash
 }
 
+/*
+	Whether or not the fox can escape through a hole
+*/
+func (d *GridDefinition) IsExit(cell int) bool {
+	for _, exit := range d.Exits {
+		if exit == cell {
+			return true
+		}
+	}
+	return false
+}
+
+/*
+	Sets the holes the fox can escape through, removing any
+	symmetries which don't map the exits onto each other.
+*/
+func (d *GridDefinition) SetExits(exits ...int) {
+	d.Exits = exits
+	d.FilterSymmetries()
+}
+
+/*
+	Cost of checking a single hole
+*/
+func (d *GridDefinition) Cost(cell int) float64 {
+	if len(d.Costs) == 0 {
+		return 1
+	}
+	return d.Costs[cell]
+}
+
+/*
+	Total cost of making a set of checks
+*/
+func (d *GridDefinition) CheckCost(checks map[int]bool) float64 {
+	total := 0.0
+	for cell := range checks {
+		total += d.Cost(cell)
+	}
+	return total
+}
+
+/*
+	Sets the cost of checking each hole, removing any symmetries
+	which map a hole onto one with a different cost. Returns an
+	error (leaving the costs as they were) unless there's a cost
+	for every hole and none of them are negative, since the
+	cheapest strategy searches rely on checks never paying back.
+*/
+func (d *GridDefinition) SetCosts(costs ...float64) error {
+
+	if len(costs) != len(d.Connections) {
+		return fmt.Errorf("got %d costs for %d holes", len(costs), len(d.Connections))
+	}
+	for cell, cost := range costs {
+		if cost < 0 {
+			return fmt.Errorf("hole %d has a negative cost of %v", cell, cost)
+		}
+	}
+
+	d.Costs = costs
+	d.FilterSymmetries()
+
+	return nil
+
+}
+
 /*
 	Function for determining if a grid definition
 	is binary or not.
+
+	For undirected grids that are connected every day, the
+	propogation of a single shaded cell cycles through every
+	parity class of the grid. Otherwise there can be cells that
+	the fox can never get back to, or sinks it can't leave, so
+	there the propogation starts with the fox anywhere and settles
+	into the cells it can still be in after enough nights have
+	passed.
+
+	For grids with a schedule, the phase of the schedule is part
+	of each grid, so the cycle is only complete once both the
+	values and the phase repeat.
 */
 func (d *GridDefinition) RepeatingGrid() (int, []*Grid) {
 
@@ -337,11 +515,15 @@ func (d *GridDefinition) RepeatingGrid() (int, []*Grid) {
 	grid := CreateBlankGrid()
 	grid.Values[0] = true
 
+	if !d.hasParityClasses() {
+		grid = CreateFullGrid()
+	}
+
 	// Loop until the last 
 	for {
 
 		for i, otherGrid := range grids {
-			if grid.Equal(otherGrid) {
+			if grid.Equal(otherGrid) && grid.Phase() == otherGrid.Phase() {
 				return len(grids) - i, grids[i:]
 			}
 		}
@@ -351,4 +533,85 @@ func (d *GridDefinition) RepeatingGrid() (int, []*Grid) {
 
 	}
 
+}
+
+/*
+	Splits a starting grid into the parity classes of the grid
+	definition (see RepeatingGrid), so each can be solved on its
+	own. Classes the start doesn't overlap are left out. When the
+	grid doesn't have parity classes, or they don't split up the
+	start cleanly in its phase of the schedule, the start is
+	returned as is.
+*/
+func (d *GridDefinition) ParityClasses(start *Grid) []*Grid {
+
+	if !d.hasParityClasses() {
+		return []*Grid{start}
+	}
+
+	_, classes := d.RepeatingGrid()
+
+	parts := []*Grid{}
+	covered := make([]bool, len(start.Values))
+	seen := map[string]bool{}
+	for _, class := range classes {
+
+		if class.Phase() != start.Phase() {
+			continue
+		}
+
+		part := start.Copy()
+		for i := range part.Values {
+			part.Values[i] = start.Values[i] && class.Values[i]
+		}
+
+		if part.NFoxes() == 0 || seen[fmt.Sprint(part.Values)] {
+			continue
+		}
+		seen[fmt.Sprint(part.Values)] = true
+
+		for i, value := range part.Values {
+			if value {
+				if covered[i] {
+					return []*Grid{start}
+				}
+				covered[i] = true
+			}
+		}
+		parts = append(parts, part)
+
+	}
+
+	for i, value := range start.Values {
+		if value && !covered[i] {
+			return []*Grid{start}
+		}
+	}
+
+	return parts
+
+}
+
+/*
+	Whether or not propogating a single cell reaches every parity
+	class of the grid. That's true when the fox can always get
+	back to where it came from and can reach every cell, on every
+	day of the schedule.
+*/
+func (d *GridDefinition) hasParityClasses() bool {
+
+	// Cells the fox can't reach from cell 0 would be left out
+	if !d.IsStronglyConnected() {
+		return false
+	}
+
+	for _, connections := range d.Schedule {
+		scheduled := &GridDefinition{Connections: connections}
+		if !scheduled.IsSymmetric() || !scheduled.IsStronglyConnected() {
+			return false
+		}
+	}
+
+	return true
+
 }
\ No newline at end of file
@@ -0,0 +1,123 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"testing"
+)
+
+func TestRepeatingGrid(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		board  *GridDefinition
+		period int
+
+		// Number of holes the fox can be in for each grid of the cycle
+		foxes []int
+	}{
+		{"path", CreateLinearGrid(4), 2, []int{2, 2}},
+		{"odd cycle", CreateCycleGrid(5), 1, []int{5}},
+
+		// The fox can't get back to 0 or 1, so it ends up stuck in 2
+		{"sink", parseBoard(t, "0 -> 1\n1 -> 2"), 1, []int{1}},
+		// The fox goes round and round, so each start is its own class
+		{"directed cycle", CreateDirectedCycleGrid(4), 4, []int{1, 1, 1, 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, test.board)
+			period, grids := test.board.RepeatingGrid()
+			if period != test.period || len(grids) != len(test.foxes) {
+				t.Fatalf("got a period of %d with %d grids, want %d with %d", period, len(grids), test.period, len(test.foxes))
+			}
+			for i, g := range grids {
+				if g.NFoxes() != test.foxes[i] {
+					t.Errorf("grid %d has %d foxes, want %d", i, g.NFoxes(), test.foxes[i])
+				}
+			}
+		})
+	}
+
+}
+
+func TestParityClasses(t *testing.T) {
+
+	tests := []struct {
+		name    string
+		board   *GridDefinition
+		classes int
+	}{
+		{"path", CreateLinearGrid(4), 2},
+		{"square", CreatePrismGrid([]int{3, 3}), 2},
+		{"odd cycle", CreateCycleGrid(5), 1},
+
+		// Without parity classes the whole board is kept, including where the fox can only be on day 0
+		{"sink", parseBoard(t, "0 -> 1\n1 -> 2"), 1},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, test.board)
+			classes := test.board.ParityClasses(CreateFullGrid())
+			if len(classes) != test.classes {
+				t.Fatalf("got %d classes, want %d", len(classes), test.classes)
+			}
+
+			// Every hole is in exactly one class
+			covered := make([]int, len(test.board.Connections))
+			for _, class := range classes {
+				for i, value := range class.Values {
+					if value {
+						covered[i]++
+					}
+				}
+			}
+			for i, count := range covered {
+				if count != 1 {
+					t.Errorf("hole %d is in %d classes", i, count)
+				}
+			}
+		})
+	}
+
+}
+
+func TestSetCosts(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		costs      []float64
+		valid      bool
+		symmetries int
+	}{
+		{"even", []float64{1, 1, 1, 1, 1}, true, 2},
+		{"symmetric", []float64{1, 1, 10, 1, 1}, true, 2},
+		{"lopsided", []float64{10, 1, 1, 1, 1}, true, 1},
+		{"too few", []float64{1, 1, 10}, false, 2},
+		{"too many", []float64{1, 1, 1, 1, 1, 1}, false, 2},
+		{"negative", []float64{1, -1, 1, 1, 1}, false, 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := CreateLinearGrid(5)
+			useBoard(t, d)
+			err := d.SetCosts(test.costs...)
+			if test.valid && err != nil {
+				t.Fatal(err)
+			}
+			if !test.valid && err == nil {
+				t.Fatal("costs should have been rejected")
+			}
+			if len(d.Symmetries) != test.symmetries {
+				t.Errorf("got %d symmetries, want %d", len(d.Symmetries), test.symmetries)
+			}
+
+			// Hashing goes through every symmetry, which compares costs
+			CreateFullGrid().Hash()
+		})
+	}
+
+}
@@ -0,0 +1,74 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseGrid(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(5))
+
+	tests := []struct {
+		text string
+		want []int
+	}{
+		{"all", []int{0, 1, 2, 3, 4}},
+		{"0,3", []int{0, 3}},
+		{" 4 , 1 ", []int{1, 4}},
+	}
+
+	for _, test := range tests {
+		g, err := ParseGrid(test.text)
+		if err != nil {
+			t.Errorf("%q: %v", test.text, err)
+			continue
+		}
+		if got := shaded(g); !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got holes %v, want %v", test.text, got, test.want)
+		}
+	}
+
+	for _, text := range []string{"", "1,x", "5", "-1"} {
+		if _, err := ParseGrid(text); err == nil {
+			t.Errorf("expected an error for %q", text)
+		}
+	}
+
+}
+
+func TestParityClassesOfStart(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(5))
+
+	tests := []struct {
+		text string
+		want [][]int
+	}{
+		{"0,1", [][]int{{0}, {1}}},
+
+		// Holes in the same class stay together
+		{"0,2,4", [][]int{{0, 2, 4}}},
+	}
+
+	for _, test := range tests {
+		start, err := ParseGrid(test.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := [][]int{}
+		for _, class := range BaseGrid.ParityClasses(start) {
+			got = append(got, shaded(class))
+		}
+		sort.Slice(got, func(a, b int) bool {
+			return got[a][0] < got[b][0]
+		})
+		if !reflect.DeepEqual(got, test.want) {
+			t.Errorf("%q: got classes %v, want %v", test.text, got, test.want)
+		}
+	}
+
+}
@@ -0,0 +1,291 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"math"
+
+	"github.com/gitchander/permutation"
+)
+
+/*
+	Directions to each neighbor of a hexagon in axial (q, r)
+	coordinates.
+*/
+var hexDirections = [][]int{
+	{1, 0},
+	{-1, 0},
+	{0, 1},
+	{0, -1},
+	{1, -1},
+	{-1, 1},
+}
+
+/*
+	Create a hexagon shaped grid of hexagonal holes. A radius
+	of 0 is a single hole, a radius of 1 is a hole surrounded
+	by 6 others and so on.
+*/
+func CreateHexagonGrid(radius int) *GridDefinition {
+
+	coordinates := [][]int{}
+	for q := -radius; q <= radius; q++ {
+		for r := -radius; r <= radius; r++ {
+			s := -q - r
+			if s >= -radius && s <= radius {
+				coordinates = append(coordinates, []int{q, r})
+			}
+		}
+	}
+
+	return createHexGrid(coordinates)
+
+}
+
+/*
+	Create a parallelogram (rhombus when width == height) shaped
+	grid of hexagonal holes.
+*/
+func CreateParallelogramHexGrid(width, height int) *GridDefinition {
+
+	coordinates := [][]int{}
+	for r := 0; r < height; r++ {
+		for q := 0; q < width; q++ {
+			coordinates = append(coordinates, []int{q, r})
+		}
+	}
+
+	return createHexGrid(coordinates)
+
+}
+
+/*
+	Builds the grid definition for any region of hexagonal holes
+	given in axial coordinates.
+*/
+func createHexGrid(coordinates [][]int) *GridDefinition {
+
+	indexes := map[string]int{}
+	for i, coordinate := range coordinates {
+		indexes[hashSymmetry(coordinate)] = i
+	}
+
+	connections := [][]int{}
+	for _, coordinate := range coordinates {
+		node := []int{}
+		for _, direction := range hexDirections {
+			neighbor := []int{coordinate[0] + direction[0], coordinate[1] + direction[1]}
+			if j, exists := indexes[hashSymmetry(neighbor)]; exists {
+				node = append(node, j)
+			}
+		}
+		connections = append(connections, node)
+	}
+
+	/*
+		The point group of the hexagonal lattice is every permutation of
+		the cube coordinates (x, y, z) = (q, -q-r, r), optionally negated.
+		That gives the rotations by 60 degrees and the 6 reflections.
+	*/
+	transforms := []func([]int) []int{}
+	for _, order := range getPermutations(3) {
+		for _, sign := range []int{1, -1} {
+			order, sign := order, sign
+			transforms = append(transforms, func(coordinate []int) []int {
+				cube := []int{coordinate[0], -coordinate[0] - coordinate[1], coordinate[1]}
+				return []int{sign * cube[order[0]], sign * cube[order[2]]}
+			})
+		}
+	}
+
+	return &GridDefinition{
+		Connections: connections,
+		Symmetries:  latticeSymmetries(coordinates, transforms),
+		Coordinates: coordinates,
+	}
+
+}
+
+/*
+	Create a triangle shaped grid of triangular holes with n
+	holes along each side (n * n holes total).
+
+	Every hole is given 3 coordinates (x, y, z). Upward pointing
+	triangles have x + y + z = n - 1 and downward pointing triangles
+	have x + y + z = n - 2. A downward triangle is connected to the
+	3 upward triangles found by adding 1 to one of its coordinates.
+*/
+func CreateTriangleGrid(n int) *GridDefinition {
+
+	coordinates := [][]int{}
+	for _, total := range []int{n - 1, n - 2} {
+		for x := 0; x <= total; x++ {
+			for y := 0; y <= total-x; y++ {
+				coordinates = append(coordinates, []int{x, y, total - x - y})
+			}
+		}
+	}
+
+	indexes := map[string]int{}
+	for i, coordinate := range coordinates {
+		indexes[hashSymmetry(coordinate)] = i
+	}
+
+	connections := make([][]int, len(coordinates))
+	for i, coordinate := range coordinates {
+		if coordinate[0]+coordinate[1]+coordinate[2] != n-2 {
+			continue
+		}
+		for axis := 0; axis < 3; axis++ {
+			neighbor := []int{coordinate[0], coordinate[1], coordinate[2]}
+			neighbor[axis]++
+			j := indexes[hashSymmetry(neighbor)]
+			connections[i] = append(connections[i], j)
+			connections[j] = append(connections[j], i)
+		}
+	}
+
+	// Rotations by 120 degrees and reflections just permute the coordinates
+	transforms := []func([]int) []int{}
+	for _, order := range getPermutations(3) {
+		order := order
+		transforms = append(transforms, func(coordinate []int) []int {
+			return []int{coordinate[order[0]], coordinate[order[1]], coordinate[order[2]]}
+		})
+	}
+
+	return &GridDefinition{
+		Connections: connections,
+		Symmetries:  latticeSymmetries(coordinates, transforms),
+		Coordinates: coordinates,
+	}
+
+}
+
+/*
+	Returns the position of the center of a hexagonal hole in the
+	plane given its axial coordinates. Neighboring centers are
+	a distance of 1 apart.
+*/
+func HexCenter(coordinate []int) (float64, float64) {
+	q, r := float64(coordinate[0]), float64(coordinate[1])
+	return q + r/2, r * math.Sqrt(3) / 2
+}
+
+/*
+	Returns the position of the center of a triangular hole in the
+	plane given its triangle coordinates. The triangle sides have
+	a length of 1 and the first corner of the board is at the origin.
+*/
+func TriangleCenter(coordinate []int, n int) (float64, float64) {
+
+	// Centers sit a third of the way into the triangle
+	offset := 1.0 / 3.0
+	if coordinate[0]+coordinate[1]+coordinate[2] == n-2 {
+		offset = 2.0 / 3.0
+	}
+	y, z := float64(coordinate[1])+offset, float64(coordinate[2])+offset
+
+	return y + z/2, z * math.Sqrt(3) / 2
+
+}
+
+/*
+	Returns every ordering of the integers 0 to n - 1
+*/
+func getPermutations(n int) [][]int {
+
+	base := []int{}
+	for i := 0; i < n; i++ {
+		base = append(base, i)
+	}
+
+	permutations := [][]int{}
+	p := permutation.New(permutation.IntSlice(base))
+	for p.Next() {
+		newPermutation := make([]int, n)
+		copy(newPermutation, base)
+		permutations = append(permutations, newPermutation)
+	}
+
+	return permutations
+
+}
+
+/*
+	Determines the symmetries of a region of a lattice. Each
+	transform is a linear map of the lattice, and a transform is
+	a symmetry of the region if, after translating it back into
+	place, it maps the region onto itself.
+*/
+func latticeSymmetries(coordinates [][]int, transforms []func([]int) []int) [][]int {
+
+	indexes := map[string]int{}
+	for i, coordinate := range coordinates {
+		indexes[hashSymmetry(coordinate)] = i
+	}
+	lowest := lowestCoordinate(coordinates)
+
+	symmetryHashes := map[string]bool{}
+	symmetries := [][]int{}
+
+transformLoop:
+	for _, transform := range transforms {
+
+		transformed := [][]int{}
+		for _, coordinate := range coordinates {
+			transformed = append(transformed, transform(coordinate))
+		}
+
+		/*
+			The lowest coordinate of a region moves with any translation,
+			so it determines the only translation which could work.
+		*/
+		transformedLowest := lowestCoordinate(transformed)
+
+		indexOrder := []int{}
+		for _, coordinate := range transformed {
+			translated := make([]int, len(coordinate))
+			for i := range coordinate {
+				translated[i] = coordinate[i] + lowest[i] - transformedLowest[i]
+			}
+
+			index, exists := indexes[hashSymmetry(translated)]
+			if !exists {
+				continue transformLoop
+			}
+			indexOrder = append(indexOrder, index)
+		}
+
+		hash := hashSymmetry(indexOrder)
+		if _, exists := symmetryHashes[hash]; !exists {
+			symmetryHashes[hash] = true
+			symmetries = append(symmetries, indexOrder)
+		}
+
+	}
+
+	return symmetries
+
+}
+
+/*
+	Returns the lexicographically lowest coordinate
+*/
+func lowestCoordinate(coordinates [][]int) []int {
+
+	lowest := coordinates[0]
+	for _, coordinate := range coordinates[1:] {
+		for i := range coordinate {
+			if coordinate[i] != lowest[i] {
+				if coordinate[i] < lowest[i] {
+					lowest = coordinate
+				}
+				break
+			}
+		}
+	}
+
+	return lowest
+
+}
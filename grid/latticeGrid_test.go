@@ -0,0 +1,43 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestLatticeGrids(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		d          *GridDefinition
+		cells      int
+		symmetries int
+		degrees    map[int]int
+	}{
+		// Every turn of a single hole is the same
+		{"single hexagon", CreateHexagonGrid(0), 1, 1, map[int]int{0: 1}},
+
+		// The 12 rotations and reflections of a hexagon
+		{"hexagon", CreateHexagonGrid(1), 7, 12, map[int]int{3: 6, 6: 1}},
+		{"larger hexagon", CreateHexagonGrid(2), 19, 12, map[int]int{3: 6, 4: 6, 6: 7}},
+
+		// A rhombus can be turned halfway and flipped along its long diagonal
+		{"rhombus", CreateParallelogramHexGrid(3, 3), 9, 4, map[int]int{2: 2, 3: 2, 4: 4, 6: 1}},
+		{"parallelogram", CreateParallelogramHexGrid(3, 2), 6, 2, map[int]int{2: 2, 3: 2, 4: 2}},
+
+		// The corners only touch one triangle, the edges two
+		{"triangle", CreateTriangleGrid(3), 9, 6, map[int]int{1: 3, 2: 3, 3: 3}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			checkDefinition(t, test.d, test.cells, test.symmetries)
+			if got := degrees(test.d); !reflect.DeepEqual(got, test.degrees) {
+				t.Errorf("got degrees %v, want %v", got, test.degrees)
+			}
+		})
+	}
+
+}
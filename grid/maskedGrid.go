@@ -0,0 +1,175 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"fmt"
+	"strings"
+)
+
+/*
+	Characters used when drawing a masked grid
+*/
+const (
+	MaskHole = '.'
+	MaskRock = '#'
+)
+
+/*
+	Create a grid by drawing it. Each line of the map is a row
+	of the grid where '.' is a hole and '#' is rock. For 3D grids
+	each layer is drawn separately, with layers separated by a
+	blank line. For example a 3x3 ring of holes looks like this:
+
+		...
+		.#.
+		...
+
+	The first axis runs along each row, the second axis down the
+	rows and the third axis through the layers.
+*/
+func CreateMaskedGrid(asciiMap string) (*GridDefinition, error) {
+	return CreateMaskedGridWithNeighborhood(asciiMap, VonNeumannNeighborhood())
+}
+
+/*
+	Same as CreateMaskedGrid, but the fox moves according to the
+	given neighborhood.
+*/
+func CreateMaskedGridWithNeighborhood(asciiMap string, neighborhood Neighborhood) (*GridDefinition, error) {
+
+	dimensionLengths, mask, err := parseMask(asciiMap)
+	if err != nil {
+		return nil, err
+	}
+
+	return CreateMaskedPrismGrid(dimensionLengths, mask, neighborhood)
+
+}
+
+/*
+	Create a prism grid where only the cells marked true in the mask
+	are holes. The mask is indexed the same way as the cells of the
+	full prism. Only the symmetries of the prism which map the mask
+	onto itself are kept.
+*/
+func CreateMaskedPrismGrid(dimensionLengths []int, mask []bool, neighborhood Neighborhood) (*GridDefinition, error) {
+
+	prism := CreatePrismGridWithNeighborhood(dimensionLengths, neighborhood)
+	if len(mask) != len(prism.Connections) {
+		return nil, fmt.Errorf("mask has %d cells but the prism has %d", len(mask), len(prism.Connections))
+	}
+
+	// Give each of the holes its new index
+	indexes := make([]int, len(mask))
+	holes := []int{}
+	for cell, isHole := range mask {
+		indexes[cell] = -1
+		if isHole {
+			indexes[cell] = len(holes)
+			holes = append(holes, cell)
+		}
+	}
+	if len(holes) == 0 {
+		return nil, fmt.Errorf("mask has no holes")
+	}
+
+	// Only keep connections between holes
+	connections := [][]int{}
+	coordinates := [][]int{}
+	for _, cell := range holes {
+		node := []int{}
+		for _, j := range prism.Connections[cell] {
+			if mask[j] {
+				node = append(node, indexes[j])
+			}
+		}
+		connections = append(connections, node)
+		coordinates = append(coordinates, prism.Coordinates[cell])
+	}
+
+	// Only keep the symmetries which map the mask onto itself
+	symmetries := [][]int{}
+symmetryLoop:
+	for _, symmetry := range prism.Symmetries {
+		for cell, isHole := range mask {
+			if isHole != mask[symmetry[cell]] {
+				continue symmetryLoop
+			}
+		}
+
+		indexOrder := []int{}
+		for _, cell := range holes {
+			indexOrder = append(indexOrder, indexes[symmetry[cell]])
+		}
+		symmetries = append(symmetries, indexOrder)
+	}
+
+	return &GridDefinition{
+		Connections: connections,
+		Symmetries:  symmetries,
+		Coordinates: coordinates,
+	}, nil
+
+}
+
+/*
+	Converts an ascii map into the dimensions of its bounding
+	prism and a mask of which cells are holes.
+*/
+func parseMask(asciiMap string) ([]int, []bool, error) {
+
+	// Split the map up into its layers of rows
+	layers := [][]string{}
+	layer := []string{}
+	for _, line := range strings.Split(asciiMap, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			if len(layer) > 0 {
+				layers = append(layers, layer)
+				layer = []string{}
+			}
+			continue
+		}
+		layer = append(layer, line)
+	}
+	if len(layer) > 0 {
+		layers = append(layers, layer)
+	}
+
+	if len(layers) == 0 {
+		return nil, nil, fmt.Errorf("map is empty")
+	}
+
+	// Every layer needs to be the same shape
+	width, height := len(layers[0][0]), len(layers[0])
+	mask := []bool{}
+	for z, layer := range layers {
+		if len(layer) != height {
+			return nil, nil, fmt.Errorf("layer %d has %d rows, expected %d", z+1, len(layer), height)
+		}
+		for y, row := range layer {
+			if len(row) != width {
+				return nil, nil, fmt.Errorf("layer %d row %d has %d cells, expected %d", z+1, y+1, len(row), width)
+			}
+			for x, c := range row {
+				switch c {
+				case MaskHole:
+					mask = append(mask, true)
+				case MaskRock:
+					mask = append(mask, false)
+				default:
+					return nil, nil, fmt.Errorf("layer %d row %d column %d has unknown character %q", z+1, y+1, x+1, c)
+				}
+			}
+		}
+	}
+
+	dimensionLengths := []int{width, height}
+	if len(layers) > 1 {
+		dimensionLengths = append(dimensionLengths, len(layers))
+	}
+
+	return dimensionLengths, mask, nil
+
+}
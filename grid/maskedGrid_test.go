@@ -0,0 +1,74 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestMaskedGrid(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		asciiMap     string
+		neighborhood Neighborhood
+		cells        int
+		symmetries   int
+		degrees      map[int]int
+	}{
+		// The rock in the middle keeps every symmetry of the square
+		{"ring", "...\n.#.\n...", VonNeumannNeighborhood(), 8, 8, map[int]int{2: 8}},
+		{"king ring", "...\n.#.\n...", MooreNeighborhood(), 8, 8, map[int]int{2: 4, 4: 4}},
+
+		// Nothing but the identity maps an L onto itself
+		{"L", "..#\n...", VonNeumannNeighborhood(), 5, 1, map[int]int{1: 1, 2: 3, 3: 1}},
+
+		// Two layers joined through the one hole they share
+		{"layers", "..\n\n#.", VonNeumannNeighborhood(), 3, 2, map[int]int{1: 2, 2: 1}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := CreateMaskedGridWithNeighborhood(test.asciiMap, test.neighborhood)
+			if err != nil {
+				t.Fatal(err)
+			}
+			checkDefinition(t, d, test.cells, test.symmetries)
+			if got := degrees(d); !reflect.DeepEqual(got, test.degrees) {
+				t.Errorf("got degrees %v, want %v", got, test.degrees)
+			}
+			if len(d.Coordinates) != test.cells {
+				t.Errorf("got %d coordinates for %d holes", len(d.Coordinates), test.cells)
+			}
+		})
+	}
+
+}
+
+func TestMaskedGridErrors(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		asciiMap string
+	}{
+		{"empty", "\n\n"},
+		{"no holes", "##\n##"},
+		{"unknown character", "..x\n..."},
+		{"ragged rows", "...\n.."},
+		{"ragged layers", "..\n..\n\n.."},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := CreateMaskedGrid(test.asciiMap); err == nil {
+				t.Errorf("expected an error for %q", test.asciiMap)
+			}
+		})
+	}
+
+	if _, err := CreateMaskedPrismGrid([]int{2, 2}, []bool{true, true, true}, VonNeumannNeighborhood()); err == nil {
+		t.Errorf("expected an error for a mask which doesn't fit the prism")
+	}
+
+}
@@ -0,0 +1,138 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"sort"
+)
+
+/*
+	A neighborhood generates every offset the fox can move
+	by in a prism grid with the given number of dimensions.
+*/
+type Neighborhood func(nDimensions int) [][]int
+
+/*
+	Axis aligned moves only. This is the original foxhole rule.
+*/
+func VonNeumannNeighborhood() Neighborhood {
+	return ManhattanNeighborhood(1)
+}
+
+/*
+	King moves, including the diagonals.
+*/
+func MooreNeighborhood() Neighborhood {
+	return ChebyshevNeighborhood(1)
+}
+
+/*
+	Every offset with a manhattan (taxicab) length of at most radius.
+*/
+func ManhattanNeighborhood(radius int) Neighborhood {
+	return func(nDimensions int) [][]int {
+		return getOffsets(nDimensions, radius, func(offset []int) bool {
+			length := 0
+			for _, x := range offset {
+				length += abs(x)
+			}
+			return length <= radius
+		})
+	}
+}
+
+/*
+	Every offset with a chebyshev (chessboard) length of at most radius.
+*/
+func ChebyshevNeighborhood(radius int) Neighborhood {
+	return func(nDimensions int) [][]int {
+		return getOffsets(nDimensions, radius, func(offset []int) bool {
+			return true
+		})
+	}
+}
+
+/*
+	Knight style leaps: 1 along one axis and 2 along another.
+*/
+func KnightNeighborhood() Neighborhood {
+	return LeaperNeighborhood(1, 2)
+}
+
+/*
+	Leaps of a along one axis and b along a different axis. Any
+	other axes are left alone.
+*/
+func LeaperNeighborhood(a, b int) Neighborhood {
+	return func(nDimensions int) [][]int {
+
+		// Sorted absolute values the offset has to match
+		leap := make([]int, nDimensions)
+		if nDimensions > 1 {
+			leap[nDimensions-2] = abs(a)
+		}
+		leap[nDimensions-1] = abs(b)
+		sort.Ints(leap)
+
+		reach := abs(a)
+		if abs(b) > reach {
+			reach = abs(b)
+		}
+
+		return getOffsets(nDimensions, reach, func(offset []int) bool {
+			lengths := []int{}
+			for _, x := range offset {
+				lengths = append(lengths, abs(x))
+			}
+			sort.Ints(lengths)
+			for i := range lengths {
+				if lengths[i] != leap[i] {
+					return false
+				}
+			}
+			return true
+		})
+	}
+}
+
+/*
+	Returns every non zero offset with each component within
+	reach which passes the include function.
+*/
+func getOffsets(nDimensions int, reach int, include func([]int) bool) [][]int {
+
+	offsets := [][]int{{}}
+	for axis := 0; axis < nDimensions; axis++ {
+		newOffsets := [][]int{}
+		for _, offset := range offsets {
+			for x := -reach; x <= reach; x++ {
+				newOffsets = append(newOffsets, append(append([]int{}, offset...), x))
+			}
+		}
+		offsets = newOffsets
+	}
+
+	included := [][]int{}
+	for _, offset := range offsets {
+		zero := true
+		for _, x := range offset {
+			if x != 0 {
+				zero = false
+			}
+		}
+		if !zero && include(offset) {
+			included = append(included, offset)
+		}
+	}
+
+	return included
+
+}
+
+// Helper for integer absolute values
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
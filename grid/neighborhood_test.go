@@ -0,0 +1,83 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNeighborhoodOffsets(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		neighborhood Neighborhood
+		nDimensions  int
+		want         int
+	}{
+		{"von neumann", VonNeumannNeighborhood(), 2, 4},
+		{"von neumann 3d", VonNeumannNeighborhood(), 3, 6},
+		{"moore", MooreNeighborhood(), 2, 8},
+		{"moore 3d", MooreNeighborhood(), 3, 26},
+		{"manhattan", ManhattanNeighborhood(2), 2, 12},
+		{"chebyshev", ChebyshevNeighborhood(2), 2, 24},
+		{"knight", KnightNeighborhood(), 2, 8},
+		{"knight 3d", KnightNeighborhood(), 3, 24},
+
+		// A leap of 0 and 1 is just a step along an axis
+		{"wazir", LeaperNeighborhood(0, 1), 2, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			offsets := test.neighborhood(test.nDimensions)
+			if len(offsets) != test.want {
+				t.Errorf("got %d offsets, want %d", len(offsets), test.want)
+			}
+			for _, offset := range offsets {
+				if len(offset) != test.nDimensions {
+					t.Errorf("offset %v doesn't have %d dimensions", offset, test.nDimensions)
+				}
+			}
+		})
+	}
+
+}
+
+func TestPrismGridWithNeighborhood(t *testing.T) {
+
+	tests := []struct {
+		name         string
+		dimensions   []int
+		neighborhood Neighborhood
+		symmetries   int
+		degrees      map[int]int
+	}{
+		{"king", []int{3, 3}, MooreNeighborhood(), 8, map[int]int{3: 4, 5: 4, 8: 1}},
+
+		// A knight in the middle of a 3x3 board can't go anywhere
+		{"knight", []int{3, 3}, KnightNeighborhood(), 8, map[int]int{0: 1, 2: 8}},
+		{"manhattan", []int{3, 3}, ManhattanNeighborhood(2), 8, map[int]int{5: 4, 6: 4, 8: 1}},
+
+		// A rectangle can't be turned a quarter
+		{"king rectangle", []int{4, 3}, MooreNeighborhood(), 4, map[int]int{3: 4, 5: 6, 8: 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := CreatePrismGridWithNeighborhood(test.dimensions, test.neighborhood)
+			checkDefinition(t, d, test.dimensions[0] * test.dimensions[1], test.symmetries)
+			if got := degrees(d); !reflect.DeepEqual(got, test.degrees) {
+				t.Errorf("got degrees %v, want %v", got, test.degrees)
+			}
+		})
+	}
+
+	// The original prism grids only move along the axes
+	plain := CreatePrismGrid([]int{4, 3})
+	vonNeumann := CreatePrismGridWithNeighborhood([]int{4, 3}, VonNeumannNeighborhood())
+	if !reflect.DeepEqual(sortedMoves(plain.Connections), sortedMoves(vonNeumann.Connections)) {
+		t.Errorf("von neumann neighborhood doesn't match the original prism grid")
+	}
+
+}
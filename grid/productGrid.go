@@ -0,0 +1,140 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+/*
+	Create the cartesian product of two grid definitions. The
+	fox moves along one of the factors while staying put in the
+	other, so the cartesian product of two lines is a prism.
+
+	Cell (i, j) of the product, with i a cell of a and j a cell
+	of b, has the index i + len(a) * j.
+*/
+func CartesianProduct(a, b *GridDefinition) *GridDefinition {
+	return createProductGrid(a, b, true, false)
+}
+
+/*
+	Create the tensor product of two grid definitions. The fox
+	has to move along both of the factors at the same time.
+*/
+func TensorProduct(a, b *GridDefinition) *GridDefinition {
+	return createProductGrid(a, b, false, true)
+}
+
+/*
+	Create the strong product of two grid definitions. The fox can
+	move along either or both of the factors, so the strong product
+	of two lines is a prism with king moves.
+*/
+func StrongProduct(a, b *GridDefinition) *GridDefinition {
+	return createProductGrid(a, b, true, true)
+}
+
+/*
+	Builds a product grid. Cartesian connections move along one
+	factor at a time and tensor connections move along both.
+*/
+func createProductGrid(a, b *GridDefinition, cartesian, tensor bool) *GridDefinition {
+
+	nA, nB := len(a.Connections), len(b.Connections)
+
+	connections := [][]int{}
+	for j := 0; j < nB; j++ {
+		for i := 0; i < nA; i++ {
+			node := []int{}
+			if cartesian {
+				for _, k := range a.Connections[i] {
+					node = append(node, k+nA*j)
+				}
+				for _, l := range b.Connections[j] {
+					node = append(node, i+nA*l)
+				}
+			}
+			if tensor {
+				for _, k := range a.Connections[i] {
+					for _, l := range b.Connections[j] {
+						node = append(node, k+nA*l)
+					}
+				}
+			}
+			connections = append(connections, node)
+		}
+	}
+
+	// Coordinates are only known if both of the factors have them
+	var coordinates [][]int
+	if a.Coordinates != nil && b.Coordinates != nil {
+		for j := 0; j < nB; j++ {
+			for i := 0; i < nA; i++ {
+				coordinate := append([]int{}, a.Coordinates[i]...)
+				coordinates = append(coordinates, append(coordinate, b.Coordinates[j]...))
+			}
+		}
+	}
+
+	// Every pair of symmetries from the factors is a symmetry of the product
+	symmetryHashes := map[string]bool{}
+	symmetries := [][]int{}
+	addSymmetry := func(indexOrder []int) {
+		hash := hashSymmetry(indexOrder)
+		if _, exists := symmetryHashes[hash]; !exists {
+			symmetryHashes[hash] = true
+			symmetries = append(symmetries, indexOrder)
+		}
+	}
+
+	for _, aSymmetry := range a.Symmetries {
+		for _, bSymmetry := range b.Symmetries {
+			indexOrder := []int{}
+			for j := 0; j < nB; j++ {
+				for i := 0; i < nA; i++ {
+					indexOrder = append(indexOrder, aSymmetry[i]+nA*bSymmetry[j])
+				}
+			}
+			addSymmetry(indexOrder)
+		}
+	}
+
+	/*
+		If the factors are the same graph then swapping them is also a
+		symmetry. Given an isomorphism f from a to b, cell (i, j) is
+		swapped with cell (f^-1(j), f(i)).
+	*/
+	if isomorphism := FindIsomorphism(a, b); isomorphism != nil {
+
+		inverse := make([]int, nA)
+		for i, j := range isomorphism {
+			inverse[j] = i
+		}
+
+		swap := []int{}
+		for j := 0; j < nB; j++ {
+			for i := 0; i < nA; i++ {
+				swap = append(swap, inverse[j]+nA*isomorphism[i])
+			}
+		}
+
+		for _, symmetry := range append([][]int{}, symmetries...) {
+			indexOrder := []int{}
+			for _, cell := range symmetry {
+				indexOrder = append(indexOrder, swap[cell])
+			}
+			addSymmetry(indexOrder)
+		}
+
+	}
+
+	definition := &GridDefinition{
+		Connections: connections,
+		Symmetries:  symmetries,
+		Coordinates: coordinates,
+		Directed:    a.Directed || b.Directed,
+	}
+
+	// Hand written factors may have listed orderings which aren't symmetries
+	definition.FilterSymmetries()
+
+	return definition
+
+}
@@ -0,0 +1,54 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProductGrids(t *testing.T) {
+
+	line := CreateLinearGrid(3)
+
+	// Products of lines are the prisms they're described as
+	tests := []struct {
+		name    string
+		product *GridDefinition
+		prism   *GridDefinition
+	}{
+		{"cartesian", CartesianProduct(line, line), CreatePrismGrid([]int{3, 3})},
+		{"strong", StrongProduct(line, line), CreatePrismGridWithNeighborhood([]int{3, 3}, MooreNeighborhood())},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+
+			// Swapping the factors is what gives the diagonal reflections
+			checkDefinition(t, test.product, 9, 8)
+			if !reflect.DeepEqual(sortedMoves(test.product.Connections), sortedMoves(test.prism.Connections)) {
+				t.Errorf("got connections %v, want %v", test.product.Connections, test.prism.Connections)
+			}
+
+		})
+	}
+
+	// Moving along both lines at once only ever moves diagonally
+	tensor := TensorProduct(line, line)
+	checkDefinition(t, tensor, 9, 8)
+	if got, want := degrees(tensor), map[int]int{1: 4, 2: 4, 4: 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got degrees %v, want %v", got, want)
+	}
+
+	// Different factors can't be swapped, leaving the 8 symmetries of the cycle times the 2 of the line
+	cylinder := CartesianProduct(CreateCycleGrid(4), line)
+	checkDefinition(t, cylinder, 12, 16)
+
+	// Following a one way loop keeps the product directed
+	directed := CartesianProduct(CreateDirectedCycleGrid(3), line)
+	checkDefinition(t, directed, 9, 6)
+	if !directed.Directed {
+		t.Errorf("product with a directed factor isn't directed")
+	}
+
+}
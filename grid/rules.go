@@ -0,0 +1,155 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+/*
+	Rules for how the fox is allowed to move each night.
+*/
+type Rules struct {
+
+	// Whether or not the fox can stay in its hole overnight
+	Stay bool
+
+	/*
+		Maximum number of tunnels the fox can move through each
+		night. The fox can't be caught while it's moving, so only
+		the hole it ends up in matters.
+	*/
+	Steps int
+}
+
+/*
+	The original puzzle rules, where the fox has to move to
+	a neighboring hole every night.
+*/
+var DefaultRules = Rules{Stay: false, Steps: 1}
+
+/*
+	The rules that will be used everywhere. Changing these
+	changes propogation, removals, repeating grids and in turn
+	every solver.
+*/
+var FoxRules = DefaultRules
+
+/*
+	Cache of the moves for a grid definition under a set of rules.
+	The table is only replaced while holding the lock, but it can be
+	read without it, since solvers look up moves constantly.
+*/
+type movesCache struct {
+	lock  sync.Mutex
+	table atomic.Value
+}
+
+// The moves for every phase of the schedule under a set of rules
+type movesTable struct {
+	rules  Rules
+	phases [][][]int
+}
+
+/*
+	Returns, for each cell, every cell the fox could be in the
+	next morning under the current FoxRules. With the default
+	rules this is just the connections, except that a fox in a
+	sink (a hole with no tunnels out of it) stays where it is.
+*/
+func (d *GridDefinition) Moves() [][]int {
+	return d.MovesOn(0)
+}
+
+/*
+	Same as Moves, but for the night after the given day. This only
+	matters for grids whose tunnels open and close over time.
+*/
+func (d *GridDefinition) MovesOn(day int) [][]int {
+	return d.movesFor(FoxRules).phases[d.Phase(day)]
+}
+
+/*
+	Returns the moves for every phase of the schedule under the given
+	rules. They're all worked out together the first time they're
+	needed, and again whenever the rules change.
+*/
+func (d *GridDefinition) movesFor(rules Rules) *movesTable {
+
+	if table, ok := d.movesCache.table.Load().(*movesTable); ok && table.rules == rules {
+		return table
+	}
+
+	d.movesCache.lock.Lock()
+	defer d.movesCache.lock.Unlock()
+
+	// Another solver could have worked them out while this one waited
+	if table, ok := d.movesCache.table.Load().(*movesTable); ok && table.rules == rules {
+		return table
+	}
+
+	table := &movesTable{rules: rules}
+	for phase := 0; phase < d.Phases(); phase++ {
+		table.phases = append(table.phases, computeMoves(d.ConnectionsOn(phase), rules))
+	}
+	d.movesCache.table.Store(table)
+
+	return table
+
+}
+
+/*
+	Determines every cell reachable from each cell by following
+	between 1 and rules.Steps connections, along with the cell
+	itself if the fox is allowed to stay. A fox that moves more
+	than one step a night only uses that night's tunnels. A fox
+	with nowhere to go stays put.
+*/
+func computeMoves(connections [][]int, rules Rules) [][]int {
+
+	steps := rules.Steps
+	if steps < 1 {
+		steps = 1
+	}
+
+	moves := [][]int{}
+	for cell := range connections {
+
+		reached := map[int]bool{}
+		cellMoves := []int{}
+		if rules.Stay {
+			reached[cell] = true
+			cellMoves = append(cellMoves, cell)
+		}
+
+		// Walk outwards one step at a time
+		frontier := map[int]bool{cell: true}
+		for step := 0; step < steps; step++ {
+			newFrontier := map[int]bool{}
+			for i := 0; i < len(connections); i++ {
+				if !frontier[i] {
+					continue
+				}
+				for _, j := range connections[i] {
+					newFrontier[j] = true
+					if !reached[j] {
+						reached[j] = true
+						cellMoves = append(cellMoves, j)
+					}
+				}
+			}
+			frontier = newFrontier
+		}
+
+		if len(cellMoves) == 0 {
+			cellMoves = append(cellMoves, cell)
+		}
+
+		moves = append(moves, cellMoves)
+
+	}
+
+	return moves
+
+}
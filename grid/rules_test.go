@@ -0,0 +1,79 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+/*
+	Swaps in the given board with the default rules for the rest of
+	a test, putting everything back once it's done
+*/
+func useBoard(t *testing.T, d *GridDefinition) {
+
+	base, rules, constraints, goal, reliability := BaseGrid, FoxRules, Constraints, HuntGoal, CheckReliability
+	t.Cleanup(func() {
+		BaseGrid, FoxRules, Constraints, HuntGoal, CheckReliability = base, rules, constraints, goal, reliability
+	})
+
+	BaseGrid = d
+	FoxRules = DefaultRules
+	Constraints = CheckConstraints{}
+	HuntGoal = Goal{}
+	CheckReliability = Reliability{}
+
+}
+
+func parseBoard(t *testing.T, text string) *GridDefinition {
+	d, err := ParseGridDefinition(text)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return d
+}
+
+func sortedMoves(moves [][]int) [][]int {
+	sorted := [][]int{}
+	for _, cellMoves := range moves {
+		cellMoves = append([]int{}, cellMoves...)
+		sort.Ints(cellMoves)
+		sorted = append(sorted, cellMoves)
+	}
+	return sorted
+}
+
+func TestMoves(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		board string
+		rules Rules
+		want  [][]int
+	}{
+		{"path", "0 - 1\n1 - 2", DefaultRules, [][]int{{1}, {0, 2}, {1}}},
+		{"stay", "0 - 1\n1 - 2", Rules{Stay: true, Steps: 1}, [][]int{{0, 1}, {0, 1, 2}, {1, 2}}},
+		{"two steps", "0 - 1\n1 - 2", Rules{Steps: 2}, [][]int{{0, 1, 2}, {0, 1, 2}, {0, 1, 2}}},
+
+		// A fox in a sink stays where it is
+		{"sink", "0 -> 1\n1 -> 2", DefaultRules, [][]int{{1}, {2}, {2}}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := parseBoard(t, test.board)
+			useBoard(t, d)
+
+			// The moves under the default rules are worked out first, so changing the rules has to replace them
+			d.Moves()
+			FoxRules = test.rules
+
+			if got := sortedMoves(d.Moves()); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+
+}
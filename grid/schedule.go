@@ -0,0 +1,108 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+/*
+	Create a grid definition where the tunnels open and close
+	from day to day. Each entry of the schedule is the connections
+	for one day. If repeats is true the schedule starts over once
+	it runs out, otherwise the last day's connections are kept.
+
+	The base definition's connections are kept for anything which
+	only cares about the layout of the board (like distances), and
+	only its symmetries which hold on every day of the schedule
+	are kept.
+*/
+func CreateScheduledGrid(base *GridDefinition, schedule [][][]int, repeats bool) *GridDefinition {
+
+	definition := &GridDefinition{
+		Connections:     base.Connections,
+		Symmetries:      base.Symmetries,
+		Coordinates:     base.Coordinates,
+		Directed:        base.Directed,
+		Exits:           base.Exits,
+		Schedule:        schedule,
+		ScheduleRepeats: repeats,
+	}
+
+	// A schedule can make a grid directed even if the base isn't
+	for _, connections := range schedule {
+		scheduled := &GridDefinition{Connections: connections}
+		if !scheduled.IsSymmetric() {
+			definition.Directed = true
+		}
+	}
+
+	definition.FilterSymmetries()
+
+	return definition
+
+}
+
+/*
+	Create a grid definition from a base definition where each
+	tunnel is only open on some days of a repeating period. The
+	open function is asked about every connection of the base on
+	every day of the period.
+*/
+func CreatePeriodicGrid(base *GridDefinition, period int, open func(day, from, to int) bool) *GridDefinition {
+
+	schedule := [][][]int{}
+	for day := 0; day < period; day++ {
+		connections := [][]int{}
+		for from, baseConnections := range base.Connections {
+			node := []int{}
+			for _, to := range baseConnections {
+				if open(day, from, to) {
+					node = append(node, to)
+				}
+			}
+			connections = append(connections, node)
+		}
+		schedule = append(schedule, connections)
+	}
+
+	return CreateScheduledGrid(base, schedule, true)
+
+}
+
+/*
+	Returns the number of different phases the schedule has.
+	Grids without a schedule only have one.
+*/
+func (d *GridDefinition) Phases() int {
+	if len(d.Schedule) == 0 {
+		return 1
+	}
+	return len(d.Schedule)
+}
+
+/*
+	Returns which phase of the schedule a day is in
+*/
+func (d *GridDefinition) Phase(day int) int {
+
+	if len(d.Schedule) == 0 {
+		return 0
+	}
+
+	if d.ScheduleRepeats {
+		return day % len(d.Schedule)
+	}
+
+	if day >= len(d.Schedule) {
+		return len(d.Schedule) - 1
+	}
+	return day
+
+}
+
+/*
+	Returns the connections which are open on a given day
+*/
+func (d *GridDefinition) ConnectionsOn(day int) [][]int {
+	if len(d.Schedule) == 0 {
+		return d.Connections
+	}
+	return d.Schedule[d.Phase(day)]
+}
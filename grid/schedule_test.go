@@ -0,0 +1,86 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPhase(t *testing.T) {
+
+	schedule := [][][]int{{{1}, {0}, {}}, {{}, {2}, {1}}, {{1}, {0, 2}, {1}}}
+
+	tests := []struct {
+		name    string
+		repeats bool
+		want    []int
+	}{
+		{"repeats", true, []int{0, 1, 2, 0, 1, 2, 0}},
+
+		// The last day of the schedule is kept forever
+		{"runs out", false, []int{0, 1, 2, 2, 2, 2, 2}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := CreateScheduledGrid(CreateLinearGrid(3), schedule, test.repeats)
+			phases := []int{}
+			for day := 0; day < len(test.want); day++ {
+				phases = append(phases, d.Phase(day))
+				if !reflect.DeepEqual(d.ConnectionsOn(day), schedule[d.Phase(day)]) {
+					t.Errorf("day %d: got connections %v, want %v", day, d.ConnectionsOn(day), schedule[d.Phase(day)])
+				}
+			}
+			if !reflect.DeepEqual(phases, test.want) {
+				t.Errorf("got phases %v, want %v", phases, test.want)
+			}
+			if d.Phases() != 3 {
+				t.Errorf("got %d phases, want 3", d.Phases())
+			}
+		})
+	}
+
+	// Grids without a schedule are always in the same phase
+	plain := CreateLinearGrid(3)
+	if plain.Phases() != 1 || plain.Phase(5) != 0 || !reflect.DeepEqual(plain.ConnectionsOn(5), plain.Connections) {
+		t.Errorf("grid without a schedule changes from day to day")
+	}
+
+}
+
+func TestScheduledGrid(t *testing.T) {
+
+	// Only the tunnels on the left half of a path of 5 are open on even days
+	d := CreatePeriodicGrid(CreateLinearGrid(5), 2, func(day, from, to int) bool {
+		return (from <= 2 && to <= 2) == (day == 0)
+	})
+	useBoard(t, d)
+
+	if err := d.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Flipping the path would swap which half is open
+	if len(d.Symmetries) != 1 {
+		t.Errorf("got %d symmetries, want just the identity", len(d.Symmetries))
+	}
+
+	// Holes cut off for the night keep the fox where it is
+	want := [][][]int{
+		{{1}, {0, 2}, {1}, {3}, {4}},
+		{{0}, {1}, {3}, {2, 4}, {3}},
+	}
+	for day, moves := range want {
+		if got := sortedMoves(d.MovesOn(day)); !reflect.DeepEqual(got, moves) {
+			t.Errorf("day %d: got moves %v, want %v", day, got, moves)
+		}
+	}
+
+	// Closing a tunnel in one direction only makes the grid directed
+	oneWay := CreateScheduledGrid(CreateLinearGrid(2), [][][]int{{{1}, {}}}, true)
+	if !oneWay.Directed {
+		t.Error("grid with a one way tunnel isn't directed")
+	}
+
+}
@@ -0,0 +1,173 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"sort"
+)
+
+/*
+	Propogates the grid where each probe clears every hole within
+	the given distance of it, rather than just the probed hole.
+*/
+func (grid *Grid) PropogateWithSensors(probes map[int]bool, radius int) *Grid {
+	return grid.PropgateWithChecks(BaseGrid.WithinDistance(probes, radius))
+}
+
+/*
+	Returns the cells which are worth probing with the given
+	radius: every cell with a possible fox position within range.
+*/
+func (grid *Grid) SensorReach(radius int) *Grid {
+
+	distances := BaseGrid.Distances()
+	reach := CreateBlankGrid()
+	for probe := range reach.Values {
+		for cell, value := range grid.Values {
+			distance := distances[probe][cell]
+			if value && distance != -1 && distance <= radius {
+				reach.Values[probe] = true
+				break
+			}
+		}
+	}
+
+	return reach
+
+}
+
+/*
+	What the hunter could see after making a set of informative
+	probes, and the grid of where the fox could be the next morning.
+*/
+type ProbeOutcome struct {
+
+	// The probes which reported the fox within range
+	Answers map[int]bool
+
+	Grid *Grid
+}
+
+/*
+	Makes informative probes. A fox in a probed hole is caught.
+	Otherwise each probe reports whether or not the fox is within
+	the given distance of it, which splits the possible fox
+	positions into one outcome per combination of answers. Only
+	outcomes where the fox hasn't been caught are returned, each
+	already propogated to the next morning.
+*/
+func (grid *Grid) Probe(probes map[int]bool, radius int) []ProbeOutcome {
+
+	distances := BaseGrid.Distances()
+
+	// Sort the probes so that the answers have a consistent order
+	probeList := []int{}
+	for probe := range probes {
+		probeList = append(probeList, probe)
+	}
+	sort.Ints(probeList)
+
+	// Group the possible fox positions by the answers they would give
+	groups := map[string]*ProbeOutcome{}
+	keys := []string{}
+	for cell, value := range grid.Values {
+		if !value || probes[cell] {
+			continue
+		}
+
+		answers := map[int]bool{}
+		key := ""
+		for _, probe := range probeList {
+			distance := distances[probe][cell]
+			if distance != -1 && distance <= radius {
+				answers[probe] = true
+				key += "1"
+			} else {
+				key += "0"
+			}
+		}
+
+		if _, exists := groups[key]; !exists {
+			outcomeGrid := CreateBlankGrid()
+			outcomeGrid.Day = grid.Day
+			groups[key] = &ProbeOutcome{
+				Answers: answers,
+				Grid:    outcomeGrid,
+			}
+			keys = append(keys, key)
+		}
+		groups[key].Grid.Values[cell] = true
+	}
+
+	outcomes := []ProbeOutcome{}
+	for _, key := range keys {
+		outcome := groups[key]
+		outcome.Grid = outcome.Grid.Propogate()
+		outcomes = append(outcomes, *outcome)
+	}
+
+	return outcomes
+
+}
+
+/*
+	Same as Probe, except that probes can miss a fox which is in
+	range (though never report one which isn't). A fox could give
+	any of the answers from the probes it's in range of not
+	noticing it, so the outcomes overlap.
+*/
+func (grid *Grid) NoisyProbe(probes map[int]bool, radius int) []ProbeOutcome {
+
+	distances := BaseGrid.Distances()
+
+	probeList := []int{}
+	for probe := range probes {
+		probeList = append(probeList, probe)
+	}
+	sort.Ints(probeList)
+
+	// Every set of probes which could report the fox
+	outcomes := []ProbeOutcome{}
+	for subset := 0; subset < POWERS[len(probeList)]; subset++ {
+
+		answers := map[int]bool{}
+		for i, probe := range probeList {
+			if subset&POWERS[i] != 0 {
+				answers[probe] = true
+			}
+		}
+
+		// The fox has to be in range of every probe which reported it
+		outcomeGrid := CreateBlankGrid()
+		outcomeGrid.Day = grid.Day
+		empty := true
+		for cell, value := range grid.Values {
+			if !value || probes[cell] {
+				continue
+			}
+			inRange := true
+			for probe := range answers {
+				distance := distances[probe][cell]
+				if distance == -1 || distance > radius {
+					inRange = false
+					break
+				}
+			}
+			if inRange {
+				outcomeGrid.Values[cell] = true
+				empty = false
+			}
+		}
+
+		if !empty {
+			outcomes = append(outcomes, ProbeOutcome{
+				Answers: answers,
+				Grid:    outcomeGrid.Propogate(),
+			})
+		}
+
+	}
+
+	return outcomes
+
+}
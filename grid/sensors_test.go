@@ -0,0 +1,104 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+// Lists the holes the fox could be in
+func shaded(g *Grid) []int {
+	cells := []int{}
+	for cell, value := range g.Values {
+		if value {
+			cells = append(cells, cell)
+		}
+	}
+	return cells
+}
+
+func TestDistances(t *testing.T) {
+
+	useBoard(t, parseBoard(t, "0 - 1\n1 - 2\n2 -> 3"))
+
+	distances := BaseGrid.Distances()
+	want := [][]int{{0, 1, 2, 3}, {1, 0, 1, 2}, {2, 1, 0, 1}, {-1, -1, -1, 0}}
+	if !reflect.DeepEqual(distances, want) {
+		t.Errorf("got distances %v, want %v", distances, want)
+	}
+
+	// Nothing can be reached from the end of a one way tunnel
+	if got, want := BaseGrid.WithinDistance(map[int]bool{3: true}, 2), map[int]bool{3: true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v within 2 of hole 3, want %v", got, want)
+	}
+	if got, want := BaseGrid.WithinDistance(map[int]bool{0: true}, 1), map[int]bool{0: true, 1: true}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v within 1 of hole 0, want %v", got, want)
+	}
+
+}
+
+func TestSensors(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(5))
+
+	// A sensor in the middle clears it and both of its neighbors
+	g := CreateFullGrid().PropogateWithSensors(map[int]bool{2: true}, 1)
+	if got, want := shaded(g), []int{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v after the sensor, want %v", got, want)
+	}
+
+	// Only holes within range of the fox are worth probing
+	start, err := ParseGrid("0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := shaded(start.SensorReach(1)), []int{0, 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got reach %v, want %v", got, want)
+	}
+
+}
+
+func TestProbe(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(5))
+
+	// A fox next to the probe ends up in an even hole, one further away in an odd hole
+	want := map[int][]int{
+		1: {0, 2, 4},
+		0: {1, 3},
+	}
+
+	outcomes := CreateFullGrid().Probe(map[int]bool{2: true}, 1)
+	if len(outcomes) != len(want) {
+		t.Fatalf("got %d outcomes, want %d", len(outcomes), len(want))
+	}
+	for _, outcome := range outcomes {
+		if got := shaded(outcome.Grid); !reflect.DeepEqual(got, want[len(outcome.Answers)]) {
+			t.Errorf("got %v for answers %v, want %v", got, outcome.Answers, want[len(outcome.Answers)])
+		}
+	}
+
+}
+
+func TestNoisyProbe(t *testing.T) {
+
+	useBoard(t, CreateLinearGrid(5))
+
+	// Hearing nothing no longer rules out the neighbors of the probe
+	want := map[int][]int{
+		1: {0, 2, 4},
+		0: {0, 1, 2, 3, 4},
+	}
+
+	outcomes := CreateFullGrid().NoisyProbe(map[int]bool{2: true}, 1)
+	if len(outcomes) != len(want) {
+		t.Fatalf("got %d outcomes, want %d", len(outcomes), len(want))
+	}
+	for _, outcome := range outcomes {
+		if got := shaded(outcome.Grid); !reflect.DeepEqual(got, want[len(outcome.Answers)]) {
+			t.Errorf("got %v for answers %v, want %v", got, outcome.Answers, want[len(outcome.Answers)])
+		}
+	}
+
+}
@@ -0,0 +1,260 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+/*
+	Determines if an ordering is a symmetry of the grid
+	definition. The ordering has to be a permutation of the
+	cells which maps every connection onto another connection,
+	on every day of the schedule if there is one, and every exit
+	onto another exit.
+*/
+func (d *GridDefinition) IsSymmetry(ordering []int) bool {
+
+	if !isSymmetry(d.Connections, ordering) {
+		return false
+	}
+
+	for _, connections := range d.Schedule {
+		if !isSymmetry(connections, ordering) {
+			return false
+		}
+	}
+
+	// Exits have to be mapped onto exits
+	for _, exit := range d.Exits {
+		if !d.IsExit(ordering[exit]) {
+			return false
+		}
+	}
+
+	// Holes can only be swapped with holes that cost the same to check
+	for cell := range d.Costs {
+		if d.Cost(ordering[cell]) != d.Cost(cell) {
+			return false
+		}
+	}
+
+	return true
+
+}
+
+/*
+	Determines if an ordering is a symmetry of a single set
+	of connections.
+*/
+func isSymmetry(connections [][]int, ordering []int) bool {
+
+	if len(ordering) != len(connections) {
+		return false
+	}
+
+	// Must use every cell exactly once
+	used := make([]bool, len(ordering))
+	for _, cell := range ordering {
+		if cell < 0 || cell >= len(ordering) || used[cell] {
+			return false
+		}
+		used[cell] = true
+	}
+
+	// Every connection has to be mapped onto a connection
+	for i, cellConnections := range connections {
+		mapped := map[int]bool{}
+		for _, j := range connections[ordering[i]] {
+			mapped[j] = true
+		}
+		if len(mapped) != len(cellConnections) {
+			return false
+		}
+		for _, j := range cellConnections {
+			if !mapped[ordering[j]] {
+				return false
+			}
+		}
+	}
+
+	return true
+
+}
+
+/*
+	Removes any orderings from the symmetries which don't
+	actually hold for the connections. Returns the number
+	of symmetries which were removed.
+*/
+func (d *GridDefinition) FilterSymmetries() int {
+
+	symmetries := [][]int{}
+	for _, symmetry := range d.Symmetries {
+		if d.IsSymmetry(symmetry) {
+			symmetries = append(symmetries, symmetry)
+		}
+	}
+
+	removed := len(d.Symmetries) - len(symmetries)
+	d.Symmetries = symmetries
+	return removed
+
+}
+
+/*
+	Finds an isomorphism between two grid definitions. The
+	returned ordering maps each cell of a onto a cell of b so
+	that connections in a are exactly the connections in b.
+	Returns nil if the definitions aren't isomorphic.
+*/
+func FindIsomorphism(a, b *GridDefinition) []int {
+
+	var isomorphism []int
+	searchIsomorphisms(a, b, func(mapping []int) bool {
+		isomorphism = append([]int{}, mapping...)
+		return false
+	})
+
+	return isomorphism
+
+}
+
+/*
+	Finds every automorphism of the grid definition. Connections
+	are treated as one way, so for directed grids the direction
+	of every tunnel is respected. The number of automorphisms can
+	be huge for grids with very few connections.
+*/
+func (d *GridDefinition) FindAutomorphisms() [][]int {
+
+	automorphisms := [][]int{}
+	searchIsomorphisms(d, d, func(mapping []int) bool {
+		automorphisms = append(automorphisms, append([]int{}, mapping...))
+		return true
+	})
+
+	return automorphisms
+
+}
+
+/*
+	Backtracking search over every isomorphism from a to b. The
+	found function is called with each isomorphism and returns
+	whether or not the search should keep going.
+*/
+func searchIsomorphisms(a, b *GridDefinition, found func([]int) bool) {
+
+	n := len(a.Connections)
+	if n != len(b.Connections) {
+		return
+	}
+
+	aConnected, aIn := connectionSets(a)
+	bConnected, bIn := connectionSets(b)
+
+	/*
+		Visit the cells of a in breadth first order so that each cell
+		(after the first of its component) is already connected to
+		something that has been mapped, which prunes the search early.
+	*/
+	order := []int{}
+	visited := make([]bool, n)
+	for start := 0; start < n; start++ {
+		if visited[start] {
+			continue
+		}
+		visited[start] = true
+		queue := []int{start}
+		for len(queue) > 0 {
+			cell := queue[0]
+			queue = queue[1:]
+			order = append(order, cell)
+			for _, j := range a.Connections[cell] {
+				if !visited[j] {
+					visited[j] = true
+					queue = append(queue, j)
+				}
+			}
+			for j := range aIn[cell] {
+				if !visited[j] {
+					visited[j] = true
+					queue = append(queue, j)
+				}
+			}
+		}
+	}
+
+	mapping := make([]int, n)
+	for i := range mapping {
+		mapping[i] = -1
+	}
+	used := make([]bool, n)
+
+	// Returns false once the search should stop
+	var search func(depth int) bool
+	search = func(depth int) bool {
+
+		if depth == n {
+			return found(mapping)
+		}
+
+		cell := order[depth]
+	candidateLoop:
+		for candidate := 0; candidate < n; candidate++ {
+
+			if used[candidate] ||
+				len(aConnected[cell]) != len(bConnected[candidate]) ||
+				len(aIn[cell]) != len(bIn[candidate]) ||
+				aConnected[cell][cell] != bConnected[candidate][candidate] {
+				continue
+			}
+
+			// Every mapped cell has to agree on the connections in both directions
+			for i := 0; i < depth; i++ {
+				other := order[i]
+				if aConnected[cell][other] != bConnected[candidate][mapping[other]] ||
+					aConnected[other][cell] != bConnected[mapping[other]][candidate] {
+					continue candidateLoop
+				}
+			}
+
+			mapping[cell] = candidate
+			used[candidate] = true
+			keepGoing := search(depth + 1)
+			mapping[cell] = -1
+			used[candidate] = false
+
+			if !keepGoing {
+				return false
+			}
+
+		}
+
+		return true
+
+	}
+
+	search(0)
+
+}
+
+/*
+	Helper function for building the outgoing and incoming
+	connections of every cell as sets.
+*/
+func connectionSets(d *GridDefinition) ([]map[int]bool, []map[int]bool) {
+
+	outgoing := make([]map[int]bool, len(d.Connections))
+	incoming := make([]map[int]bool, len(d.Connections))
+	for i := range d.Connections {
+		outgoing[i] = map[int]bool{}
+		incoming[i] = map[int]bool{}
+	}
+
+	for i, connections := range d.Connections {
+		for _, j := range connections {
+			outgoing[i][j] = true
+			incoming[j][i] = true
+		}
+	}
+
+	return outgoing, incoming
+
+}
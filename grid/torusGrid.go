@@ -0,0 +1,189 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"github.com/gitchander/permutation"
+)
+
+/*
+	Helper function for creating a cycle of n holes. The
+	first and last holes are connected to each other.
+*/
+func CreateCycleGrid(n int) *GridDefinition {
+	return CreateTorusGrid([]int{n}, []bool{true})
+}
+
+/*
+	Create n-cube grid where selected axes wrap around. An axis
+	which wraps connects the first and last cell along that axis,
+	so a 2D grid with both axes wrapped is a torus and a 1D grid
+	with its axis wrapped is a cycle.
+*/
+func CreateTorusGrid(dimensionLengths []int, wrap []bool) *GridDefinition {
+
+	dimensionSizes, totalCells := getDimensionSizes(dimensionLengths)
+
+	// Generate the connections array first
+	connections := [][]int{}
+	coordinates := [][]int{}
+	for i := 0; i < totalCells; i++ {
+		location := getLocation(dimensionSizes, i)
+		coordinates = append(coordinates, location)
+
+		/*
+			Generate each of the modified locations based on the current location.
+			Short wrapped axes can produce the same neighbor twice (or the cell
+			itself) so those are filtered out.
+		*/
+		connectionLocations := []int{}
+		seen := map[int]bool{i: true}
+		for axis, x := range location {
+			length := dimensionLengths[axis]
+
+			for _, step := range []int{-1, 1} {
+				newX := x + step
+				if wrap[axis] {
+					newX = (newX + length) % length
+				} else if newX < 0 || newX >= length {
+					continue
+				}
+
+				newLocation := make([]int, len(location))
+				copy(newLocation, location)
+				newLocation[axis] = newX
+
+				index := getIndex(dimensionSizes, newLocation)
+				if !seen[index] {
+					seen[index] = true
+					connectionLocations = append(connectionLocations, index)
+				}
+			}
+		}
+
+		connections = append(connections, connectionLocations)
+
+	}
+
+	return &GridDefinition{
+		Connections: connections,
+		Symmetries:  torusSymmetries(dimensionLengths, wrap),
+		Coordinates: coordinates,
+	}
+
+}
+
+/*
+	Helper function for computing the size of each dimension
+	used to index and un-index locations, as well as the total
+	number of cells.
+*/
+func getDimensionSizes(dimensionLengths []int) ([]int, int) {
+	totalCells := 1
+	dimensionSizes := []int{}
+	for _, l := range dimensionLengths {
+		dimensionSizes = append(dimensionSizes, totalCells)
+		totalCells *= l
+	}
+	return dimensionSizes, totalCells
+}
+
+/*
+	Generates the full symmetry group of a (partially) wrapped
+	prism. Every element is a combination of:
+		- a permutation of the axes (only between axes of equal
+		  length that either both wrap or both don't)
+		- a reflection of each axis
+		- a rotation along each wrapped axis
+*/
+func torusSymmetries(dimensionLengths []int, wrap []bool) [][]int {
+
+	dimensionSizes, totalCells := getDimensionSizes(dimensionLengths)
+	nDimensions := len(dimensionLengths)
+
+	// Collect every valid permutation of the axes
+	axes := []int{}
+	for i := 0; i < nDimensions; i++ {
+		axes = append(axes, i)
+	}
+	axisPermutations := [][]int{}
+	p := permutation.New(permutation.IntSlice(axes))
+	for p.Next() {
+		valid := true
+		for from, to := range axes {
+			if dimensionLengths[from] != dimensionLengths[to] || wrap[from] != wrap[to] {
+				valid = false
+				break
+			}
+		}
+		if valid {
+			newPermutation := make([]int, nDimensions)
+			copy(newPermutation, axes)
+			axisPermutations = append(axisPermutations, newPermutation)
+		}
+	}
+
+	/*
+		Collect every combination of reflections and rotations. Each
+		transform is stored as a pair of values per axis: whether or
+		not the axis is reflected and how far it's rotated.
+	*/
+	reflections := [][]bool{{}}
+	rotations := [][]int{{}}
+	for axis := 0; axis < nDimensions; axis++ {
+
+		newReflections := [][]bool{}
+		newRotations := [][]int{}
+
+		shifts := 1
+		if wrap[axis] {
+			shifts = dimensionLengths[axis]
+		}
+
+		for i, reflection := range reflections {
+			for _, flip := range []bool{false, true} {
+				for shift := 0; shift < shifts; shift++ {
+					newReflection := append(append([]bool{}, reflection...), flip)
+					newRotation := append(append([]int{}, rotations[i]...), shift)
+					newReflections = append(newReflections, newReflection)
+					newRotations = append(newRotations, newRotation)
+				}
+			}
+		}
+
+		reflections, rotations = newReflections, newRotations
+
+	}
+
+	// Apply each combination to every cell to build the orderings
+	symmetryHashes := map[string]bool{}
+	symmetries := [][]int{}
+	for _, axisPermutation := range axisPermutations {
+		for i, reflection := range reflections {
+			rotation := rotations[i]
+
+			indexOrder := []int{}
+			for cell := 0; cell < totalCells; cell++ {
+				location := getLocation(dimensionSizes, cell)
+				newLocation := make([]int, nDimensions)
+				for axis, x := range location {
+					length := dimensionLengths[axis]
+					if reflection[axis] {
+						x = length - 1 - x
+					}
+					newLocation[axisPermutation[axis]] = (x + rotation[axis]) % length
+				}
+				indexOrder = append(indexOrder, getIndex(dimensionSizes, newLocation))
+			}
+
+			hash := hashSymmetry(indexOrder)
+			if _, exists := symmetryHashes[hash]; !exists {
+				symmetryHashes[hash] = true
+				symmetries = append(symmetries, indexOrder)
+			}
+		}
+	}
+
+	return symmetries
+
+}
@@ -0,0 +1,89 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package grid
+
+import (
+	"reflect"
+	"testing"
+)
+
+/*
+	Checks a generated board is well formed, has the expected number
+	of cells and symmetries, and that no symmetry is listed twice
+*/
+func checkDefinition(t *testing.T, d *GridDefinition, cells, symmetries int) {
+
+	t.Helper()
+	if err := d.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if len(d.Connections) != cells {
+		t.Errorf("got %d cells, want %d", len(d.Connections), cells)
+	}
+	if len(d.Symmetries) != symmetries {
+		t.Errorf("got %d symmetries, want %d", len(d.Symmetries), symmetries)
+	}
+
+	seen := map[string]bool{}
+	for _, symmetry := range d.Symmetries {
+		if seen[hashSymmetry(symmetry)] {
+			t.Errorf("symmetry %v is listed more than once", symmetry)
+		}
+		seen[hashSymmetry(symmetry)] = true
+	}
+
+}
+
+// Counts how many cells have each number of connections
+func degrees(d *GridDefinition) map[int]int {
+	counts := map[int]int{}
+	for _, connections := range d.Connections {
+		counts[len(connections)]++
+	}
+	return counts
+}
+
+func TestCycleGrid(t *testing.T) {
+
+	for _, n := range []int{3, 4, 5, 8} {
+		d := CreateCycleGrid(n)
+
+		// Rotations and reflections of the cycle
+		checkDefinition(t, d, n, 2 * n)
+		if got := degrees(d); got[2] != n {
+			t.Errorf("cycle of %d: got degrees %v, want every cell to have 2", n, got)
+		}
+	}
+
+}
+
+func TestTorusGrid(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		dimensions []int
+		wrap       []bool
+		symmetries int
+		degrees    map[int]int
+	}{
+		// Every translation, along with the 8 symmetries of a square
+		{"square torus", []int{5, 5}, []bool{true, true}, 200, map[int]int{4: 25}},
+
+		// Without the quarter turns
+		{"rectangular torus", []int{4, 3}, []bool{true, true}, 48, map[int]int{4: 12}},
+
+		// Turning around the loop, and flipping either way
+		{"cylinder", []int{5, 3}, []bool{true, false}, 20, map[int]int{3: 10, 4: 5}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d := CreateTorusGrid(test.dimensions, test.wrap)
+			checkDefinition(t, d, test.dimensions[0] * test.dimensions[1], test.symmetries)
+			if got := degrees(d); !reflect.DeepEqual(got, test.degrees) {
+				t.Errorf("got degrees %v, want %v", got, test.degrees)
+			}
+		})
+	}
+
+}
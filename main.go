@@ -3,17 +3,87 @@
 package main
 
 import (
-	// "fmt"
-	// "foxhole/grid"
+	"flag"
+	"fmt"
+	"foxhole/grid"
 	"foxhole/solvers"
+	"os"
 )
 
 func main() {
 
+	start := flag.String("start", "", "holes the fox could start in, \"all\" or a list like 0,3,5 (defaults to each parity class)")
+	checks := flag.Int("checks", 5, "number of checks per day")
+	nSolvers := flag.Int("solvers", 12, "number of concurrent solvers")
+	play := flag.Bool("play", false, "play the hunter against the fox instead of solving")
+	showPossible := flag.Bool("show", false, "when playing, mark the holes the fox could be in")
+	flag.Parse()
+
+	if *play {
+		solvers.PlayGame(os.Stdin, os.Stdout, *checks, 0, *showPossible)
+		return
+	}
+
 	// fmt.Println(len(solvers.Combinations(5, 2)))
 
 	// fmt.Println(solvers.Hashes)
-	solvers.Solve(solvers.Brute, 5, 12)
+	if *start == "" {
+		solvers.Solve(solvers.Brute, *checks, *nSolvers)
+	} else {
+		startGrid, err := grid.ParseGrid(*start)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		solvers.SolveFrom(solvers.Brute, startGrid, *checks, *nSolvers)
+	}
+
+	// Hunting 2 foxes at once, starting anywhere
+	// fmt.Println(solvers.SolveFoxes(grid.CreateFullGrid(), 2, false, 1, 0))
+
+	// Sensors which clear every hole within a distance of 1
+	// solvers.Solve(solvers.SensorBrute(1), 1, 12)
+
+	// Checking 1 hole and watching 1 tunnel each day
+	// solvers.Solve(solvers.EdgeBrute(1), 1, 12)
+
+	// 2 hunters walking around the board, starting wherever they like
+	// fmt.Println(solvers.SolveHuntersAnywhere(grid.CreateFullGrid(), 2, true, 0))
+
+	// Probes which report if the fox is within a distance of 1
+	// fmt.Println(solvers.SolveProbes(grid.CreateFullGrid(), 1, 1, 20))
+
+	// The same probes, except they can miss the fox
+	// fmt.Println(solvers.SolveNoisyProbes(grid.CreateFullGrid(), 1, 1, 20))
+
+	// Holes which cost more to check, solved for the lowest total cost
+	// grid.BaseGrid = grid.CreateLinearGrid(5)
+	// grid.BaseGrid.SetCosts(1, 1, 10, 1, 1)
+	// fmt.Println(solvers.SolveCheapest(solvers.Exhaustive, grid.CreateFullGrid(), 1, false, 0))
+
+	// 2 checks one day and 3 the next, or 20 checks to spend over the whole hunt
+	// fmt.Println(solvers.SolveBudget(solvers.Brute, grid.CreateFullGrid(), solvers.Budget{PerDay: []int{2, 3}}, 0))
+	// fmt.Println(solvers.SolveBudget(solvers.Brute, grid.CreateFullGrid(), solvers.Budget{Total: 20}, 0))
+
+	// A board in separate pieces, with each piece hunted on its own
+	// fmt.Println(solvers.SolveComponents(solvers.Brute, 2, 0))
+
+	// Only narrowing the fox down to at most 3 holes
+	// grid.HuntGoal = grid.Goal{MaxHoles: 3}
+
+	// Known strategies for lines, loops and ladders, searching for anything else
+	// fmt.Println(solvers.SolveKnown(solvers.Brute, 1, 0))
+
+	// A fox which moves at random
+	// start := grid.CreateUniformDistribution(grid.CreateFullGrid())
+	// strategy, curve := solvers.MaximizeCapture(start, 1, 6)
+	// fmt.Println(solvers.FormatCaptureCurve(strategy, curve))
+
+	// Checks which miss the fox a fifth of the time
+	// grid.CheckReliability = grid.Reliability{Miss: 0.2}
+	// strategy, curve, _ = solvers.FewestDaysToConfidence(start, 1, 0.85, 10)
+	// fmt.Println(solvers.FormatCaptureCurve(strategy, curve))
+	// fmt.Println("Where the fox is if it got away:", solvers.PosteriorAfter(start, strategy).Probabilities)
 
 	// g := grid.CreateBlankGrid()
 	// g.Values[0] = true
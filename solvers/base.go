@@ -25,11 +25,20 @@ var GridsToProcess = make(chan *grid.Grid)
 */
 type SolverFunction func(*grid.Grid, int) []*grid.Grid
 
+/*
+	Key for a state of the search. The same arrangement of the grid
+	is a different state in a different phase of the schedule.
+*/
+type StateKey struct {
+	Hash  int
+	Phase int
+}
+
 /*
 	Current hashes. Used for very quickly identifying if a certain
 	arrangement of the grid has been reached before.
 */
-var Hashes = make(map[int]bool)
+var Hashes = make(map[StateKey]bool)
 var HashLock = sync.Mutex{}
 
 /*
@@ -38,6 +47,14 @@ var HashLock = sync.Mutex{}
 var Solution *grid.Grid
 var SolutionLock = sync.Mutex{}
 
+/*
+	Solutions for each parity class from the last solve, and the
+	single strategy combining them which catches the fox no matter
+	which class it started in. See UnifyStrategies.
+*/
+var Solutions []*grid.Grid
+var UnifiedSolution []map[int]bool
+
 /*
 	Used for tracking the current depth
 */
@@ -58,7 +75,7 @@ var TestLock = sync.Mutex{}
 func reset() {
 
 	GridsToProcess = make(chan *grid.Grid)
-	Hashes = make(map[int]bool)
+	Hashes = make(map[StateKey]bool)
 
 	Solution = nil
 
@@ -132,23 +149,30 @@ func processGrids(grids []*grid.Grid, gridSize int) {
 		handling the wait groups appropriately.
 	*/
 	if Solution != nil {
+		Depths[gridSize + 1].Done()
 		solverWaitGroup.Done()
 		return
 	}
 
 	// Pre-compute all the hashes
 	indices := []int{}
-	hashes := []int{}
+	hashes := []StateKey{}
 	for i, grid := range grids {
+
+		// The fox got away, so there's nothing more to do with this grid
+		if grid.Escaped {
+			continue
+		}
+
 		hash := grid.Hash()
 
-		// No possible locations for the fox
-		if hash == 0 {
+		// No possible locations for the fox (or few enough, see grid.HuntGoal)
+		if goalReached(grid) {
 			SolutionLock.Lock()
 			Solution = grid
 			SolutionLock.Unlock()
 		} else {
-			hashes = append(hashes, hash)
+			hashes = append(hashes, StateKey{hash, grid.Phase()})
 			indices = append(indices, i)
 		}
 
@@ -183,6 +207,14 @@ func processGrids(grids []*grid.Grid, gridSize int) {
 
 }
 
+/*
+	Whether or not a grid meets grid.HuntGoal. Needed where grid
+	is used as a variable name.
+*/
+func goalReached(g *grid.Grid) bool {
+	return grid.HuntGoal.Reached(g)
+}
+
 // Used to track how many solvers are currently processing
 var solverWaitGroup = sync.WaitGroup{}
 var solverKillChannel = make(chan bool)
@@ -205,12 +237,70 @@ func Solve(
 
 	/*
 		Create the base case where the fox can
-		be anywhere in the grid.
+		be anywhere in the grid, split up into its
+		parity classes. On boards where the fox can
+		get stuck (see RepeatingGrid) there aren't
+		any classes, so the whole board is hunted.
 	*/
-	repetition, grids := grid.BaseGrid.RepeatingGrid()
+	grids := grid.BaseGrid.ParityClasses(grid.CreateFullGrid())
+
+	// Pieces of the board the fox can't move between are best hunted separately
+	if components := grid.BaseGrid.ConnectedComponents(); len(components) > 1 && DEBUG {
+		fmt.Println("Connected Components:", len(components), "(see SolveComponents)")
+	}
+	if grid.BaseGrid.Directed && DEBUG {
+		fmt.Println("Strongly Connected Components:", len(grid.BaseGrid.StronglyConnectedComponents()))
+	}
+
+	solveClasses(solver, checks, nSolvers, grids)
+
+}
+
+/*
+	Same as Solve, but starting from any grid of holes the fox
+	could be in. The start is split into the parity classes of
+	BaseGrid and each is solved separately. An empty start is
+	already solved, with nothing to check.
+*/
+func SolveFrom(
+
+	// The solving function to use
+	solver SolverFunction,
+
+	// Holes the fox could be in to begin with
+	start *grid.Grid,
+
+	// Number of check that can be performed per day
+	checks int,
+
+	// Number of concurrent threads
+	nSolvers int,
+
+) {
+
+	solveClasses(solver, checks, nSolvers, grid.BaseGrid.ParityClasses(start))
+
+}
+
+/*
+	Runs the concurrent solve from each of the starting grids, then
+	combines the solutions (see UnifyStrategies), searching from every
+	grid at once if they can't be combined (see SearchUnified)
+*/
+func solveClasses(solver SolverFunction, checks int, nSolvers int, grids []*grid.Grid) {
+
+	Solutions = nil
+	UnifiedSolution = nil
+
+	// With no holes to start in the fox is already caught
+	if len(grids) == 0 {
+		fmt.Println("The fox can't be anywhere, so there's nothing to check")
+		UnifiedSolution = []map[int]bool{}
+		return
+	}
 
 	// Try for each solution type
-	for i := 0; i < repetition; i++ {
+	for i := range grids {
 
 		// Log how long a solve is taking
 		t0 := time.Now()
@@ -218,6 +308,7 @@ func Solve(
 		baseGrid := grids[i]
 
 		if DEBUG {
+			fmt.Println("Fox Rules:", grid.FoxRules)
 			fmt.Println("Base Grid:", baseGrid)
 		}
 
@@ -232,7 +323,7 @@ func Solve(
 		// Add the baseGrid to the grids to process to get everything started.
 		solverWaitGroup.Add(1)
 		Depths[0].Add(1)
-		Hashes[baseGrid.Hash()] = true
+		Hashes[StateKey{baseGrid.Hash(), baseGrid.Phase()}] = true
 		GridsToProcess <- baseGrid
 
 		// Await for all the processing to complete
@@ -248,17 +339,60 @@ func Solve(
 		if Solution != nil {
 			fmt.Println("Solution", Solution)
 			fmt.Println("Solution Length", len(Solution.Checks))
+			if Solution.NFoxes() > 0 {
+				fmt.Println("Fox Narrowed Down To:", Solution.NFoxes(), "holes")
+			}
 			fmt.Println("Total Hashes:", len(Hashes))
 			fmt.Println("Time to Process:", fmt.Sprintf("%.2f", float64(time.Since(t0)) / float64(time.Second)), "seconds")
 		} else {
 			fmt.Println("No Solutions Found")
+			if len(grid.BaseGrid.Exits) > 0 {
+				// Every check is tried so the escape really is forced
+				if day, forced := EarliestEscape(Exhaustive, baseGrid, checks, 0); forced {
+					fmt.Println("Earliest Forced Escape: Day", day)
+				} else {
+					fmt.Println("Escape can be prevented, but the fox can't be caught")
+				}
+			}
 			fmt.Println("Time to Process:", fmt.Sprintf("%.2f", float64(time.Since(t0)) / float64(time.Second)), "seconds")
 		}
 
-		if DEBUG {
-			break
+		// Without every class there's no way to combine them
+		if Solution == nil {
+			return
+		}
+		Solutions = append(Solutions, Solution)
+	}
+
+	if len(grids) < 2 {
+		UnifiedSolution = Solutions[0].Checks
+		return
+	}
+
+	strategies := [][]map[int]bool{}
+	longest := 0
+	for _, solution := range Solutions {
+		strategies = append(strategies, solution.Checks)
+		if len(solution.Checks) > longest {
+			longest = len(solution.Checks)
+		}
+	}
+
+	fmt.Println()
+	unified, err := UnifyStrategies(grids, strategies, checks)
+	if err != nil {
+		fmt.Println("Unable to Combine Solutions:", err)
+		fmt.Println("Searching From Every Class at Once")
+		unified, err = SearchUnified(solver, grids, checks)
+		if err != nil {
+			fmt.Println("Unable to Find a Unified Solution:", err)
+			return
 		}
 	}
+	UnifiedSolution = unified
+	fmt.Println("Unified Solution", unified)
+	fmt.Println("Longest Class Solution Length", longest)
+	fmt.Println("Unified Solution Length", len(unified))
 
 }
 
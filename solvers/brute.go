@@ -12,7 +12,16 @@ import (
 	until everything is exhausted.
 */
 func Brute(originalGrid *grid.Grid, checks int) []*grid.Grid {
-	return recursiveBrute(originalGrid, checks, map[int]bool{}, map[int]bool{})
+
+	resultingGrids := recursiveBrute(originalGrid, checks, map[int]bool{}, map[int]bool{})
+
+	// Nothing allowed by the constraints, so the best that can be done is waiting
+	if len(resultingGrids) == 0 {
+		resultingGrids = append(resultingGrids, originalGrid.PropogateWithChecksAndAdd(map[int]bool{}))
+	}
+
+	return resultingGrids
+
 }
 
 /*
@@ -29,14 +38,32 @@ func recursiveBrute(originalGrid *grid.Grid, checksLeft int, checksMade, hashes
 
 	// Can't remove anything if here
 	if checksLeft <= 0 {
-		newGrid := originalGrid.PropogateWithChecksAndAdd(checksMade)
-		resultingGrids = append(resultingGrids, newGrid)
+		if grid.Constraints.Allows(checksMade) {
+			newGrid := originalGrid.PropogateWithChecksAndAdd(checksMade)
+			resultingGrids = append(resultingGrids, newGrid)
+		}
 		return resultingGrids
 	}
 
 	// Determine what holes can be removed
 	removalOptions := originalGrid.HowToRemove(checksMade)
 
+	// Checking an exit the fox could be in stops it from escaping
+	for _, exit := range grid.BaseGrid.Exits {
+		if originalGrid.Values[exit] && !checksMade[exit] {
+			removalOptions = append(removalOptions, map[int]bool{exit: true})
+		}
+	}
+
+	// Connected checks can need holes the fox isn't in to join them up
+	if grid.Constraints.Connected && len(checksMade) > 0 {
+		for cell := range grid.BaseGrid.NearbyCells(checksMade, 1) {
+			if !checksMade[cell] && !grid.Constraints.Forbidden[cell] {
+				removalOptions = append(removalOptions, map[int]bool{cell: true})
+			}
+		}
+	}
+
 	// fmt.Println("Removals:", removalOptions)
 	for _, option := range removalOptions {
 
@@ -55,6 +82,11 @@ func recursiveBrute(originalGrid *grid.Grid, checksLeft int, checksMade, hashes
 		*/
 		SetUnion(option, checksMade)
 
+		// Checks which break the constraints can't be fixed by checking more
+		if !grid.Constraints.AllowsPartial(option) {
+			continue
+		}
+
 		optionHash := SetHash(option)
 		if _, exists := hashes[optionHash]; !exists {
 			hashes[optionHash] = true
@@ -68,7 +100,7 @@ func recursiveBrute(originalGrid *grid.Grid, checksLeft int, checksMade, hashes
 		
 	}
 
-	if len(resultingGrids) == 0 {
+	if len(resultingGrids) == 0 && grid.Constraints.Allows(checksMade) {
 		newGrid := originalGrid.PropogateWithChecksAndAdd(checksMade)
 		resultingGrids = append(resultingGrids, newGrid)
 		return resultingGrids
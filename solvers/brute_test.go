@@ -0,0 +1,52 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestConnectedChecks(t *testing.T) {
+
+	tests := []struct {
+		dimensions []int
+		checks     int
+		want       int
+
+		// Exhaustive takes too long on the bigger boards
+		bruteOnly bool
+	}{
+		{[]int{3, 3}, 3, 10, false},
+		{[]int{3, 3}, 4, 4, false},
+		{[]int{4, 3}, 3, 16, false},
+		{[]int{4, 4}, 4, 12, true},
+	}
+
+	for _, test := range tests {
+		if test.bruteOnly && testing.Short() {
+			continue
+		}
+		solvers := map[string]SolverFunction{"Brute": Brute, "Exhaustive": Exhaustive}
+		if test.bruteOnly {
+			delete(solvers, "Exhaustive")
+		}
+		for name, solver := range solvers {
+			useBoard(t, grid.CreatePrismGrid(test.dimensions))
+			setConstraints(t, grid.CheckConstraints{Connected: true})
+
+			strategy, _, found := SolveCheapest(solver, grid.CreateFullGrid(), test.checks, true, 20)
+			if !found {
+				t.Errorf("%s found nothing on %v with %d checks", name, test.dimensions, test.checks)
+				continue
+			}
+			if len(strategy) != test.want {
+				t.Errorf("%s took %d days on %v with %d checks, want %d", name, len(strategy), test.dimensions, test.checks, test.want)
+			}
+			if err := VerifyStrategy(grid.CreateFullGrid(), strategy, test.checks); err != nil {
+				t.Errorf("%s on %v: %v", name, test.dimensions, err)
+			}
+		}
+	}
+
+}
@@ -0,0 +1,146 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"fmt"
+	"foxhole/grid"
+)
+
+/*
+	How many checks the hunter has to spend. Solve only allows
+	the same number of checks every day, a budget can also change
+	that number from day to day or limit the checks for the whole
+	hunt.
+*/
+type Budget struct {
+
+	/*
+		Checks available on each day, starting over once it runs out
+		(so {2, 3} is 2, 3, 2, 3...). Empty if the only limit is Total.
+	*/
+	PerDay []int
+
+	/*
+		Checks available over the whole hunt, which can be spent
+		however the hunter likes. 0 means there's no total limit.
+	*/
+	Total int
+}
+
+/*
+	Checks which can be made on a day, given how many are left
+	in the total budget
+*/
+func (b Budget) Available(day, remaining int) int {
+
+	available := remaining
+	if len(b.PerDay) > 0 {
+		perDay := b.PerDay[day % len(b.PerDay)]
+		if b.Total <= 0 || perDay < available {
+			available = perDay
+		}
+	}
+	return available
+
+}
+
+/*
+	Search state for a single fox under a budget. The day of the
+	PerDay schedule and the checks left in the total budget are
+	both part of the state, since the same grid with fewer checks
+	left isn't as good.
+*/
+type budgetState struct {
+	grid      *grid.Grid
+	solver    SolverFunction
+	budget    Budget
+	day       int
+	remaining int
+}
+
+func (s *budgetState) Key() string {
+	scheduleDay := 0
+	if len(s.budget.PerDay) > 0 {
+		scheduleDay = s.day % len(s.budget.PerDay)
+	}
+	return fmt.Sprint(s.grid.Phase(), ":", scheduleDay, ":", s.remaining, ":", s.grid.Hash())
+}
+
+func (s *budgetState) Solved() bool {
+	return grid.HuntGoal.Reached(s.grid)
+}
+
+/*
+	With a total budget it can be worth saving checks for later,
+	so every number of checks up to what's available is tried.
+	Otherwise unused checks are lost and only the full amount is.
+*/
+func (s *budgetState) Successors() []Step {
+
+	available := s.budget.Available(s.day, s.remaining)
+	fewest := available
+	if s.budget.Total > 0 {
+		fewest = 0
+	}
+
+	steps := []Step{}
+	seen := map[string]bool{}
+	for c := available; c >= fewest; c-- {
+		for _, newGrid := range s.solver(s.grid, c) {
+
+			if newGrid.Escaped {
+				continue
+			}
+
+			checks := newGrid.Checks[len(newGrid.Checks) - 1]
+			if seen[formatChecks(checks)] {
+				continue
+			}
+			seen[formatChecks(checks)] = true
+
+			remaining := s.remaining
+			if s.budget.Total > 0 {
+				remaining -= len(checks)
+			}
+
+			steps = append(steps, Step{
+				Checks: checks,
+				State: &budgetState{
+					grid:      newGrid,
+					solver:    s.solver,
+					budget:    s.budget,
+					day:       s.day + 1,
+					remaining: remaining,
+				},
+			})
+
+		}
+	}
+
+	return steps
+
+}
+
+/*
+	Solves the hunt starting from the given grid with the checks
+	limited by a budget instead of a fixed number per day. Returns
+	the checks to make each day of the shortest strategy and whether
+	or not one was found within maxDays (no limit if not positive).
+*/
+func SolveBudget(solver SolverFunction, start *grid.Grid, budget Budget, maxDays int) ([]map[int]bool, bool) {
+
+	if len(budget.PerDay) == 0 && budget.Total <= 0 {
+		return nil, false
+	}
+
+	state := &budgetState{
+		grid:      start,
+		solver:    solver,
+		budget:    budget,
+		remaining: budget.Total,
+	}
+
+	return Search(state, maxDays)
+
+}
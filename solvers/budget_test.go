@@ -0,0 +1,88 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestBudgetAvailable(t *testing.T) {
+
+	tests := []struct {
+		budget    Budget
+		day       int
+		remaining int
+		want      int
+	}{
+		{Budget{PerDay: []int{2, 3}}, 0, 0, 2},
+		{Budget{PerDay: []int{2, 3}}, 3, 0, 3},
+		{Budget{Total: 10}, 4, 7, 7},
+
+		// Whichever limit is lower
+		{Budget{PerDay: []int{2}, Total: 10}, 0, 1, 1},
+		{Budget{PerDay: []int{2}, Total: 10}, 0, 5, 2},
+	}
+
+	for _, test := range tests {
+		if got := test.budget.Available(test.day, test.remaining); got != test.want {
+			t.Errorf("%+v on day %d with %d left: got %d checks, want %d", test.budget, test.day, test.remaining, got, test.want)
+		}
+	}
+
+}
+
+func TestSolveBudget(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		budget Budget
+		days   int
+		found  bool
+	}{
+		{"same every day", Budget{PerDay: []int{1}}, 6, true},
+		{"alternating", Budget{PerDay: []int{1, 2}}, 4, true},
+		{"days off", Budget{PerDay: []int{0, 2}}, 6, true},
+
+		// Enough to check every hole at once
+		{"total", Budget{Total: 6}, 1, true},
+		{"small total", Budget{Total: 4}, 2, true},
+		{"too small", Budget{Total: 3}, 0, false},
+
+		// The plain hunt needs a check on each of 6 days
+		{"both", Budget{PerDay: []int{1}, Total: 5}, 0, false},
+		{"no budget", Budget{}, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+
+			strategy, found := SolveBudget(Brute, grid.CreateFullGrid(), test.budget, 10)
+			if found != test.found {
+				t.Fatalf("found a strategy %v, want %v", found, test.found)
+			}
+			if !found {
+				return
+			}
+			if len(strategy) != test.days {
+				t.Errorf("strategy %v takes %d days, want %d", strategy, len(strategy), test.days)
+			}
+
+			// Stays within the budget and catches the fox
+			spent := 0
+			current := grid.CreateFullGrid()
+			for day, checks := range strategy {
+				if available := test.budget.Available(day, test.budget.Total - spent); len(checks) > available {
+					t.Errorf("day %d makes %d checks with only %d available", day+1, len(checks), available)
+				}
+				spent += len(checks)
+				current = current.PropgateWithChecks(checks)
+			}
+			if current.NFoxes() != 0 {
+				t.Errorf("strategy %v doesn't catch the fox", strategy)
+			}
+		})
+	}
+
+}
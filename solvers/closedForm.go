@@ -0,0 +1,156 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+)
+
+/*
+	A strategy for a family of grids which is known without
+	searching. Each family needs a certain number of checks per day
+	and gives back candidate strategies, the first of which that
+	replays correctly is used.
+*/
+type closedForm struct {
+	name       string
+	checks     int
+	strategies func(d *grid.GridDefinition) [][]map[int]bool
+}
+
+var closedForms = []closedForm{
+
+	/*
+		A line of holes with 1 check. Checking holes 1 to n - 2 and
+		then back again is the shortest strategy, taking 2(n - 2) days.
+	*/
+	{"path", 1, func(d *grid.GridDefinition) [][]map[int]bool {
+
+		order, isPath := d.PathOrder()
+		if !isPath {
+			return nil
+		}
+
+		// Too short to sweep, so just keep checking one end
+		if len(order) <= 2 {
+			strategy := []map[int]bool{}
+			for range order {
+				strategy = append(strategy, map[int]bool{order[0]: true})
+			}
+			return [][]map[int]bool{strategy}
+		}
+
+		strategy := []map[int]bool{}
+		for i := 1; i <= len(order) - 2; i++ {
+			strategy = append(strategy, map[int]bool{order[i]: true})
+		}
+		for i := len(order) - 2; i >= 1; i-- {
+			strategy = append(strategy, map[int]bool{order[i]: true})
+		}
+		return [][]map[int]bool{strategy}
+
+	}},
+
+	/*
+		A loop with an even number of holes and 2 checks. The checks
+		close in from both sides of hole 0, twice over (once for each
+		parity class), which takes n - 2 days. Loops with an odd
+		number of holes don't have a simple pattern and are searched.
+	*/
+	{"cycle", 2, func(d *grid.GridDefinition) [][]map[int]bool {
+
+		order, isCycle := d.CycleOrder()
+		if !isCycle || len(order) % 2 != 0 {
+			return nil
+		}
+
+		n := len(order)
+		sweep := func(shift int) []map[int]bool {
+			days := []map[int]bool{}
+			for i := 1; i <= (n - 1) / 2; i++ {
+				days = append(days, map[int]bool{order[i - shift]: true, order[n - i - shift]: true})
+			}
+			return days
+		}
+
+		// The second sweep has to start on the other parity
+		return [][]map[int]bool{
+			append(sweep(0), sweep(0)...),
+			append(sweep(0), sweep(1)...),
+		}
+
+	}},
+
+	/*
+		2 rows of holes side by side with 2 checks. A diagonal pair of
+		holes sweeps along the rows, twice over, which takes 2(n - 1)
+		days for rows of n holes.
+	*/
+	{"ladder", 2, func(d *grid.GridDefinition) [][]map[int]bool {
+
+		rows, isLadder := d.LadderOrder()
+		if !isLadder {
+			return nil
+		}
+
+		n := len(rows[0])
+		sweep := func(top, bottom int) []map[int]bool {
+			days := []map[int]bool{}
+			for x := n - 2; x >= 0; x-- {
+				days = append(days, map[int]bool{rows[0][x + top]: true, rows[1][x + bottom]: true})
+			}
+			return days
+		}
+
+		return [][]map[int]bool{
+			append(sweep(0, 1), sweep(0, 1)...),
+			append(sweep(0, 1), sweep(1, 0)...),
+		}
+
+	}},
+}
+
+/*
+	Looks for a known strategy for the BaseGrid with the given
+	number of checks per day. Only plain hunts are recognized: the
+	default fox rules, no schedule and the fox has to be caught.
+	Every strategy is replayed with VerifyStrategy before it's
+	returned. Returns the strategy, the name of the family and
+	whether or not one was found.
+*/
+func ClosedForm(checks int) ([]map[int]bool, string, bool) {
+
+	base := grid.BaseGrid
+	if grid.FoxRules != grid.DefaultRules || len(base.Schedule) > 0 || !grid.HuntGoal.IsCapture() {
+		return nil, "", false
+	}
+
+	for _, form := range closedForms {
+		if form.checks != checks {
+			continue
+		}
+		for _, strategy := range form.strategies(base) {
+			if VerifyStrategy(grid.CreateFullGrid(), strategy, checks) == nil {
+				return strategy, form.name, true
+			}
+		}
+	}
+
+	return nil, "", false
+
+}
+
+/*
+	Finds a strategy which catches the fox wherever it starts, using
+	a known strategy if there is one (see ClosedForm) and searching
+	with the solver otherwise.
+*/
+func SolveKnown(solver SolverFunction, checks int, maxDays int) ([]map[int]bool, bool) {
+
+	if strategy, _, found := ClosedForm(checks); found {
+		return strategy, true
+	}
+
+	return Search(&gridState{grid: grid.CreateFullGrid(), solver: solver, checks: checks}, maxDays)
+
+}
@@ -0,0 +1,82 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestClosedForm(t *testing.T) {
+
+	scrambled, err := grid.ParseGridDefinition("2 - 0\n0 - 4\n4 - 1\n1 - 3")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		board  *grid.GridDefinition
+		checks int
+		family string
+		days   int
+	}{
+		{"path of 2", grid.CreateLinearGrid(2), 1, "path", 2},
+		{"path of 3", grid.CreateLinearGrid(3), 1, "path", 2},
+		{"path of 6", grid.CreateLinearGrid(6), 1, "path", 8},
+		{"scrambled path", scrambled, 1, "path", 6},
+		{"cycle of 4", grid.CreateCycleGrid(4), 2, "cycle", 2},
+		{"cycle of 8", grid.CreateCycleGrid(8), 2, "cycle", 6},
+		{"ladder of 3", grid.CreatePrismGrid([]int{3, 2}), 2, "ladder", 4},
+		{"ladder of 5", grid.CreatePrismGrid([]int{5, 2}), 2, "ladder", 8},
+
+		// Not in any family
+		{"odd cycle", grid.CreateCycleGrid(5), 2, "", 0},
+		{"square", grid.CreatePrismGrid([]int{3, 3}), 2, "", 0},
+		{"path with 2 checks", grid.CreateLinearGrid(5), 2, "", 0},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, test.board)
+
+			strategy, family, found := ClosedForm(test.checks)
+			if found != (test.family != "") || family != test.family {
+				t.Fatalf("got family %q (found: %v), want %q", family, found, test.family)
+			}
+			if !found {
+				return
+			}
+			if len(strategy) != test.days {
+				t.Errorf("got %d days, want %d", len(strategy), test.days)
+			}
+
+			// Replaying every day through PropgateWithChecks has to catch the fox
+			g := grid.CreateFullGrid()
+			for _, checks := range strategy {
+				g = g.PropgateWithChecks(checks)
+			}
+			if g.NFoxes() != 0 {
+				t.Errorf("the fox could still be in %v", g.Values)
+			}
+
+			// And nothing shorter exists
+			searched, found := Search(&gridState{grid: grid.CreateFullGrid(), solver: Brute, checks: test.checks}, 0)
+			if !found || len(searched) != len(strategy) {
+				t.Errorf("searching found %d days, the closed form took %d", len(searched), len(strategy))
+			}
+		})
+	}
+
+}
+
+func TestClosedFormOnlyForPlainHunts(t *testing.T) {
+
+	useBoard(t, grid.CreateLinearGrid(5))
+	grid.FoxRules = grid.Rules{Stay: true, Steps: 1}
+
+	if _, _, found := ClosedForm(1); found {
+		t.Error("used the path strategy for a fox which can stay put")
+	}
+
+}
@@ -0,0 +1,199 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+)
+
+/*
+	Solves boards made up of pieces the fox can't move between (see
+	GridDefinition.ConnectedComponents) by solving each piece on its
+	own. The pieces are then either hunted one after another with
+	every check, or all at once with the checks split between them,
+	whichever is shorter.
+
+	Returns the checks to make each day, a lower bound on the length
+	of any strategy (the longest piece on its own) and whether or not
+	a strategy was found which holds up on the whole board (see
+	VerifyStrategy). When the length of the strategy matches the lower
+	bound it's optimal.
+*/
+func SolveComponents(solver SolverFunction, checks int, maxDays int) ([]map[int]bool, int, bool) {
+
+	base := grid.BaseGrid
+	components := base.ConnectedComponents()
+
+	// Strategies for each piece with each number of checks per day
+	type key struct{ component, checks int }
+	strategies := map[key][]map[int]bool{}
+	solve := func(component, componentChecks, day int) ([]map[int]bool, bool) {
+		if day == 0 {
+			if strategy, exists := strategies[key{component, componentChecks}]; exists {
+				return strategy, true
+			}
+		}
+		strategy, found := solveComponent(solver, components[component], componentChecks, day, maxDays)
+		if found && day == 0 {
+			strategies[key{component, componentChecks}] = strategy
+		}
+		return strategy, found
+	}
+
+	lowerBound := 0
+	for component := range components {
+		strategy, found := solve(component, checks, 0)
+		if !found {
+			return nil, 0, false
+		}
+		if len(strategy) > lowerBound {
+			lowerBound = len(strategy)
+		}
+	}
+
+	/*
+		One after another. The fox in the pieces still to come can be
+		anywhere in them by the time they're hunted, so each piece is
+		solved from the day it starts in case the schedule has moved on.
+	*/
+	sequential := []map[int]bool{}
+	for component := range components {
+		day := len(sequential)
+		if base.Phase(day) == base.Phase(0) {
+			day = 0
+		}
+		strategy, found := solve(component, checks, day)
+		if !found {
+			sequential = nil
+			break
+		}
+		sequential = append(sequential, strategy...)
+	}
+
+	/*
+		Each piece was solved with the constraints renumbered for that
+		piece alone, so a combined strategy might still break them on the
+		whole board (e.g. checks in two pieces aren't connected). Only
+		strategies which hold up on the whole board are kept.
+	*/
+	valid := func(strategy []map[int]bool) bool {
+		return strategy != nil && VerifyStrategy(grid.CreateFullGrid(), strategy, checks) == nil
+	}
+
+	var best []map[int]bool
+	if valid(sequential) {
+		best = sequential
+	}
+
+	// All at once, trying every way of splitting up the checks
+	if len(components) > 1 && len(components) <= checks {
+
+		// Every piece was already solved within maxDays, so any split will do
+		bestLength := int(^uint(0) >> 1)
+		if best != nil {
+			bestLength = len(best)
+		}
+
+		split := make([]int, len(components))
+		var searchSplits func(component, checksLeft, length int)
+		searchSplits = func(component, checksLeft, length int) {
+
+			if length >= bestLength {
+				return
+			}
+			if component == len(components) {
+				merged := make([]map[int]bool, length)
+				for day := range merged {
+					merged[day] = map[int]bool{}
+				}
+				for component, c := range split {
+					strategy, _ := solve(component, c, 0)
+					for day, dayChecks := range strategy {
+						SetUnion(merged[day], dayChecks)
+					}
+				}
+				if valid(merged) {
+					best = merged
+					bestLength = length
+				}
+				return
+			}
+
+			// Leave at least one check for each of the other pieces
+			for c := 1; c <= checksLeft - (len(components) - component - 1); c++ {
+				strategy, found := solve(component, c, 0)
+				if !found {
+					continue
+				}
+				split[component] = c
+				newLength := length
+				if len(strategy) > newLength {
+					newLength = len(strategy)
+				}
+				searchSplits(component + 1, checksLeft - c, newLength)
+			}
+
+		}
+		searchSplits(0, checks, 0)
+
+	}
+
+	if best == nil {
+		return nil, 0, false
+	}
+
+	return best, lowerBound, true
+
+}
+
+/*
+	Solves a single piece of the BaseGrid starting on the given
+	day, with the fox anywhere in it. The BaseGrid and constraints
+	are swapped out for the piece while it's solved. The checks
+	returned use the cells of the BaseGrid.
+*/
+func solveComponent(solver SolverFunction, cells []int, checks, day, maxDays int) ([]map[int]bool, bool) {
+
+	base, constraints := grid.BaseGrid, grid.Constraints
+	defer func() {
+		grid.BaseGrid, grid.Constraints = base, constraints
+	}()
+
+	indexes := map[int]int{}
+	for i, cell := range cells {
+		indexes[cell] = i
+	}
+
+	grid.BaseGrid = base.Subgrid(cells)
+	subConstraints := grid.CheckConstraints{
+		Forbidden:   map[int]bool{},
+		Connected:   constraints.Connected,
+		MinDistance: constraints.MinDistance,
+	}
+	for hole := range constraints.Forbidden {
+		if index, exists := indexes[hole]; exists {
+			subConstraints.Forbidden[index] = true
+		}
+	}
+
+	// Every hole was just renumbered inside the piece, so this can't fail
+	grid.SetConstraints(subConstraints)
+
+	start := grid.CreateFullGrid()
+	start.Day = day
+	strategy, found := Search(&gridState{grid: start, solver: solver, checks: checks}, maxDays)
+	if !found {
+		return nil, false
+	}
+
+	for i, dayChecks := range strategy {
+		baseChecks := map[int]bool{}
+		for cell := range dayChecks {
+			baseChecks[cells[cell]] = true
+		}
+		strategy[i] = baseChecks
+	}
+
+	return strategy, true
+
+}
@@ -0,0 +1,59 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestSolveComponents(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		constraints grid.CheckConstraints
+		checks      int
+		days        int
+		lowerBound  int
+	}{
+		// Hunting the path of 3 and then the path of 5
+		{"one check", grid.CheckConstraints{}, 1, 8, 6},
+		{"two checks", grid.CheckConstraints{}, 2, 4, 2},
+
+		// Checks in different pieces are never connected, so they can't be hunted at the same time
+		{"connected", grid.CheckConstraints{Connected: true}, 2, 6, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			d, err := grid.CreateMaskedGrid("...#.....")
+			if err != nil {
+				t.Fatal(err)
+			}
+			useBoard(t, d)
+			setConstraints(t, test.constraints)
+
+			strategy, lowerBound, found := SolveComponents(Brute, test.checks, 20)
+			if !found {
+				t.Fatal("no strategy found")
+			}
+			if len(strategy) != test.days || lowerBound != test.lowerBound {
+				t.Errorf("got %d days with a lower bound of %d, want %d and %d: %v", len(strategy), lowerBound, test.days, test.lowerBound, strategy)
+			}
+			if err := VerifyStrategy(grid.CreateFullGrid(), strategy, test.checks); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// A piece that can't be solved means the board can't be either
+	d, err := grid.CreateMaskedGrid("...\n.#.\n...\n###\n...")
+	if err != nil {
+		t.Fatal(err)
+	}
+	useBoard(t, d)
+	if _, _, found := SolveComponents(Brute, 1, 20); found {
+		t.Error("found a strategy with a ring that can't be hunted with one check")
+	}
+
+}
@@ -0,0 +1,132 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"fmt"
+	"foxhole/grid"
+)
+
+/*
+	Search state for a single fox, using a SolverFunction to
+	decide which checks to try each day
+*/
+type gridState struct {
+	grid   *grid.Grid
+	solver SolverFunction
+	checks int
+}
+
+func (s *gridState) Key() string {
+	return fmt.Sprint(s.grid.Phase(), ":", s.grid.Hash())
+}
+
+func (s *gridState) Solved() bool {
+	return grid.HuntGoal.Reached(s.grid)
+}
+
+/*
+	Each grid the solver returns has the checks it made added on
+	the end. Grids the fox escaped from are dead ends.
+*/
+func (s *gridState) Successors() []Step {
+
+	steps := []Step{}
+	for _, newGrid := range s.solver(s.grid, s.checks) {
+		if newGrid.Escaped {
+			continue
+		}
+		steps = append(steps, Step{
+			Checks: newGrid.Checks[len(newGrid.Checks) - 1],
+			State: &gridState{
+				grid:   newGrid,
+				solver: s.solver,
+				checks: s.checks,
+			},
+		})
+	}
+
+	return steps
+
+}
+
+/*
+	Solver which tries every set of at most checks holes the fox
+	could be in (that grid.Constraints allows). Much slower than
+	Brute, but it doesn't skip checks which are cheap without
+	being needed for a removal.
+*/
+func Exhaustive(originalGrid *grid.Grid, checks int) []*grid.Grid {
+
+	type resultKey struct {
+		hash    int
+		escaped bool
+	}
+
+	/*
+		Check sets which leave the fox in the same holes are the same
+		as far as the search is concerned, so only the cheapest is kept
+	*/
+	resultingGrids := []*grid.Grid{}
+	results := map[resultKey]int{}
+	seen := map[string]bool{}
+	for c := checks; c >= 0; c-- {
+		for _, cellChecks := range checkCombinations(originalGrid, c) {
+
+			if seen[formatChecks(cellChecks)] {
+				continue
+			}
+			seen[formatChecks(cellChecks)] = true
+
+			newGrid := originalGrid.PropogateWithChecksAndAdd(cellChecks)
+			key := resultKey{newGrid.Hash(), newGrid.Escaped}
+			if i, exists := results[key]; exists {
+				previous := resultingGrids[i].Checks[len(resultingGrids[i].Checks) - 1]
+				if grid.BaseGrid.CheckCost(cellChecks) < grid.BaseGrid.CheckCost(previous) {
+					resultingGrids[i] = newGrid
+				}
+				continue
+			}
+			results[key] = len(resultingGrids)
+			resultingGrids = append(resultingGrids, newGrid)
+
+		}
+	}
+
+	return resultingGrids
+
+}
+
+/*
+	Finds the strategy with the lowest total cost of checks (see
+	GridDefinition.Costs) starting from the given grid. When
+	lexicographic is true the strategy takes as few days as possible
+	and only then is as cheap as possible. Returns the checks to make
+	each day, the total cost and whether or not a strategy was found
+	within maxDays (no limit if not positive).
+*/
+func SolveCheapest(solver SolverFunction, start *grid.Grid, checks int, lexicographic bool, maxDays int) ([]map[int]bool, float64, bool) {
+
+	state := &gridState{
+		grid:   start,
+		solver: solver,
+		checks: checks,
+	}
+
+	cost := func(step Step) float64 {
+		return grid.BaseGrid.CheckCost(step.Checks)
+	}
+
+	path, total, found := CheapestPath(state, cost, lexicographic, maxDays)
+	if !found {
+		return nil, 0, false
+	}
+
+	strategy := []map[int]bool{}
+	for _, step := range path {
+		strategy = append(strategy, step.Checks)
+	}
+
+	return strategy, total, true
+
+}
@@ -0,0 +1,69 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestSolveCheapest(t *testing.T) {
+
+	tests := []struct {
+		name          string
+		costs         []float64
+		checks        int
+		lexicographic bool
+		days          int
+		cost          float64
+	}{
+		{"even", nil, 1, false, 6, 6},
+		{"expensive middle", []float64{1, 1, 10, 1, 1}, 1, false, 6, 24},
+
+		// Checking 1 and 3 twice never needs the expensive middle
+		{"expensive middle with 2", []float64{1, 1, 10, 1, 1}, 2, false, 2, 4},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+			if test.costs != nil {
+				if err := grid.BaseGrid.SetCosts(test.costs...); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			strategy, cost, found := SolveCheapest(Exhaustive, grid.CreateFullGrid(), test.checks, test.lexicographic, 0)
+			if !found {
+				t.Fatal("no strategy found")
+			}
+			if len(strategy) != test.days || cost != test.cost {
+				t.Errorf("got %d days costing %v, want %d costing %v", len(strategy), cost, test.days, test.cost)
+			}
+			if err := VerifyStrategy(grid.CreateFullGrid(), strategy, test.checks); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+}
+
+func TestExhaustiveKeepsCheapest(t *testing.T) {
+
+	// Checking 0, 2 or nothing on a path of 3 all leave the fox in 1, so the expensive 0 is never kept
+	useBoard(t, grid.CreateLinearGrid(3))
+	if err := grid.BaseGrid.SetCosts(5, 1, 1); err != nil {
+		t.Fatal(err)
+	}
+
+	start := grid.CreateBlankGrid()
+	start.Values[0], start.Values[2] = true, true
+
+	for _, newGrid := range Exhaustive(start, 1) {
+		checks := newGrid.Checks[len(newGrid.Checks) - 1]
+		if checks[0] {
+			t.Errorf("checking 0 for %v was kept over checking 2", newGrid.Values)
+		}
+	}
+
+}
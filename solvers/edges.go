@@ -0,0 +1,49 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+)
+
+/*
+	Brute force solver for hunters who can watch tunnels as well
+	as check holes. The checks passed in by Solve are the number
+	of holes checked each day, and edges is the number of tunnels
+	watched each day. Can be passed straight to Solve.
+*/
+func EdgeBrute(edges int) SolverFunction {
+	return func(originalGrid *grid.Grid, checks int) []*grid.Grid {
+
+		// Every way of watching the tunnels the fox could use tonight
+		usefulEdges := originalGrid.UsefulEdges()
+		edgeOptions := []map[int]bool{{}}
+		if edges > 0 && len(usefulEdges) > 0 {
+			edgeOptions = []map[int]bool{}
+			count := edges
+			if count > len(usefulEdges) {
+				count = len(usefulEdges)
+			}
+			for _, combination := range Combinations(len(usefulEdges), count) {
+				watched := map[int]bool{}
+				for i := range combination {
+					watched[usefulEdges[i]] = true
+				}
+				edgeOptions = append(edgeOptions, watched)
+			}
+		}
+
+		resultingGrids := []*grid.Grid{}
+		for _, holes := range checkCombinations(originalGrid, checks) {
+			for _, watched := range edgeOptions {
+				newGrid := originalGrid.PropogateWithEdgeChecks(holes, watched)
+				newGrid.AddChecks(holes)
+				newGrid.EdgeChecks = append(newGrid.EdgeChecks, watched)
+				resultingGrids = append(resultingGrids, newGrid)
+			}
+		}
+
+		return resultingGrids
+
+	}
+}
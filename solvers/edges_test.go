@@ -0,0 +1,48 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestEdgeBrute(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		board  *grid.GridDefinition
+		checks int
+		edges  int
+		days   int
+		found  bool
+	}{
+		// Without watching any tunnels it's the original hunt
+		{"holes only", grid.CreateLinearGrid(5), 1, 0, 6, true},
+		{"one tunnel", grid.CreateLinearGrid(5), 1, 1, 3, true},
+		{"two tunnels", grid.CreateLinearGrid(5), 1, 2, 2, true},
+
+		// A fox moving every night has to cross a watched tunnel eventually
+		{"tunnels only", grid.CreateLinearGrid(5), 0, 1, 7, true},
+		{"nothing", grid.CreateLinearGrid(5), 0, 0, 0, false},
+
+		// One check can't catch a fox on a loop, but watching a tunnel as well can
+		{"loop", grid.CreateCycleGrid(6), 1, 1, 6, true},
+		{"loop without tunnels", grid.CreateCycleGrid(6), 1, 0, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, test.board)
+
+			strategy, found := Search(&gridState{grid: grid.CreateFullGrid(), solver: EdgeBrute(test.edges), checks: test.checks}, 12)
+			if found != test.found {
+				t.Fatalf("found a strategy %v, want %v", found, test.found)
+			}
+			if found && len(strategy) != test.days {
+				t.Errorf("strategy %v takes %d days, want %d", strategy, len(strategy), test.days)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,81 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"fmt"
+	"foxhole/grid"
+	"sort"
+)
+
+/*
+	Determines the first day by which the fox is sure to have
+	escaped through one of the BaseGrid exits, no matter what the
+	hunter does. Returns false if the hunter can keep the fox from
+	escaping for at least maxDays (or can catch it). If maxDays is
+	not positive there's no limit: the search stops once the grids
+	the hunter could have reached are the same as on an earlier day,
+	since from then on the hunter can keep the fox in forever.
+
+	Each day keeps every grid the hunter could have reached without
+	the fox escaping. Once there are none left, the escape was forced.
+	The hunter only gets the checks the solver offers, so an escape is
+	only forced among those. Brute skips checks it doesn't think are
+	needed, so use Exhaustive to be sure the fox can't be kept in.
+*/
+func EarliestEscape(solver SolverFunction, start *grid.Grid, checks int, maxDays int) (int, bool) {
+
+	layer := []*grid.Grid{start}
+	layers := map[string]bool{}
+	for day := 1; maxDays <= 0 || day <= maxDays; day++ {
+
+		seen := map[StateKey]bool{}
+		newLayer := []*grid.Grid{}
+		for _, g := range layer {
+			for _, newGrid := range solver(g, checks) {
+
+				if newGrid.Escaped {
+					continue
+				}
+
+				// The hunter can catch the fox before it escapes
+				if grid.HuntGoal.Reached(newGrid) {
+					return 0, false
+				}
+
+				key := StateKey{newGrid.Hash(), newGrid.Phase()}
+				if !seen[key] {
+					seen[key] = true
+					newLayer = append(newLayer, newGrid)
+				}
+
+			}
+		}
+
+		if len(newLayer) == 0 {
+			return day, true
+		}
+		layer = newLayer
+
+		// The same grids as before, so this goes on forever
+		keys := []StateKey{}
+		for key := range seen {
+			keys = append(keys, key)
+		}
+		sort.Slice(keys, func(i, j int) bool {
+			if keys[i].Hash != keys[j].Hash {
+				return keys[i].Hash < keys[j].Hash
+			}
+			return keys[i].Phase < keys[j].Phase
+		})
+		layerKey := fmt.Sprint(keys)
+		if layers[layerKey] {
+			return 0, false
+		}
+		layers[layerKey] = true
+
+	}
+
+	return 0, false
+
+}
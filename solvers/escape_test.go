@@ -0,0 +1,85 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestEarliestEscape(t *testing.T) {
+
+	tests := []struct {
+		name  string
+		board *grid.GridDefinition
+		exits []int
+
+		// Holes the fox starts in, or anywhere if nil
+		start []int
+
+		checks  int
+		maxDays int
+		day     int
+		forced  bool
+	}{
+		// Nothing stops a fox next to the exit
+		{"no checks", grid.CreateLinearGrid(5), []int{4}, nil, 0, 0, 1, true},
+
+		// Checking the exit every day keeps the fox in forever, which is found without a limit
+		{"guarded exit", grid.CreateCycleGrid(6), []int{0}, nil, 1, 0, 0, false},
+
+		// Both ends can't be guarded with 1 check
+		{"two exits", grid.CreateLinearGrid(5), []int{0, 4}, nil, 1, 0, 1, true},
+
+		// 2 checks on a path of 5 catch the fox outright
+		{"caught", grid.CreateLinearGrid(5), []int{4}, nil, 2, 0, 0, false},
+
+		// From the middle of a path of 7 the fox takes 4 nights to get out
+		{"from the middle", grid.CreateLinearGrid(7), []int{0, 6}, []int{3}, 0, 0, 4, true},
+
+		// With a limit the search gives up before then
+		{"limited", grid.CreateLinearGrid(7), []int{0, 6}, []int{3}, 0, 2, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, test.board)
+			test.board.SetExits(test.exits...)
+
+			start := grid.CreateFullGrid()
+			if test.start != nil {
+				start = grid.CreateBlankGrid()
+				for _, hole := range test.start {
+					start.Values[hole] = true
+				}
+			}
+
+			day, forced := EarliestEscape(Exhaustive, start, test.checks, test.maxDays)
+			if day != test.day || forced != test.forced {
+				t.Errorf("got day %d (forced: %v), want day %d (forced: %v)", day, forced, test.day, test.forced)
+			}
+		})
+	}
+
+}
+
+func TestSolveWithExits(t *testing.T) {
+
+	// A strategy has to catch the fox before it can reach the exit
+	useBoard(t, grid.CreateLinearGrid(7))
+	grid.BaseGrid.SetExits(0)
+
+	strategy, found := Search(&gridState{grid: grid.CreateFullGrid(), solver: Brute, checks: 2}, 0)
+	if !found {
+		t.Fatal("no strategy found")
+	}
+	if err := VerifyStrategy(grid.CreateFullGrid(), strategy, 2); err != nil {
+		t.Error(err)
+	}
+
+	// Leaving the exit unchecked on the first day lets the fox out
+	if err := VerifyStrategy(grid.CreateFullGrid(), []map[int]bool{{3: true, 5: true}}, 2); err == nil {
+		t.Error("the fox should have escaped")
+	}
+
+}
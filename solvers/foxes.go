@@ -0,0 +1,59 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+)
+
+/*
+	Search state for hunting several foxes at once
+*/
+type foxesState struct {
+	foxes  *grid.Foxes
+	checks int
+}
+
+func (s *foxesState) Key() string {
+	return s.foxes.Hash()
+}
+
+func (s *foxesState) Solved() bool {
+	return s.foxes.Caught()
+}
+
+/*
+	Checking a hole no fox can be in is pointless, so only
+	combinations of the holes which could have a fox are tried.
+*/
+func (s *foxesState) Successors() []Step {
+
+	steps := []Step{}
+	for _, checks := range checkCombinations(s.foxes.Grid(), s.checks) {
+		steps = append(steps, Step{
+			Checks: checks,
+			State: &foxesState{
+				foxes:  s.foxes.PropogateWithChecks(checks),
+				checks: s.checks,
+			},
+		})
+	}
+
+	return steps
+
+}
+
+/*
+	Solves the hunt for n foxes which could each start anywhere in
+	the start grid. Returns the checks to make each day.
+*/
+func SolveFoxes(start *grid.Grid, n int, distinct bool, checks int, maxDays int) ([]map[int]bool, bool) {
+
+	state := &foxesState{
+		foxes:  grid.CreateFoxes(start, n, distinct),
+		checks: checks,
+	}
+
+	return Search(state, maxDays)
+
+}
@@ -0,0 +1,49 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestSolveFoxes(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		n        int
+		distinct bool
+	}{
+		{"one fox", 1, true},
+		{"two foxes", 2, true},
+		{"two foxes sharing holes", 2, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+
+			/*
+				Checks which catch a fox wherever it starts catch every
+				fox, so more foxes don't make the hunt any longer
+			*/
+			strategy, found := SolveFoxes(grid.CreateFullGrid(), test.n, test.distinct, 1, 8)
+			if !found {
+				t.Fatal("no strategy found")
+			}
+			if len(strategy) != 6 {
+				t.Errorf("strategy %v takes %d days, want 6", strategy, len(strategy))
+			}
+			if err := VerifyStrategy(grid.CreateFullGrid(), strategy, 1); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+	// A single check can never catch a fox going round a loop
+	useBoard(t, grid.CreateCycleGrid(4))
+	if _, found := SolveFoxes(grid.CreateFullGrid(), 2, true, 1, 8); found {
+		t.Error("found a strategy for foxes on a loop with a single check")
+	}
+
+}
@@ -0,0 +1,253 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"bufio"
+	"fmt"
+	"foxhole/grid"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/*
+	Plays the puzzle in the terminal. The player picks the holes to
+	check each day and the fox does whatever keeps it alive the
+	longest.
+
+	The fox never actually commits to a hole. It could be in any
+	hole it could have reached without being caught (the same grid
+	PropgateWithChecks keeps track of), so it's only caught once
+	there's nowhere left for it. If the player gives up, runs out of
+	days (no limit if maxDays isn't positive) or lets it escape, a
+	path the fox could have taken is shown.
+
+	When showPossible is true the board marks every hole the fox
+	could be in. Returns whether or not the fox was caught.
+*/
+func PlayGame(in io.Reader, out io.Writer, checks int, maxDays int, showPossible bool) bool {
+
+	reader := bufio.NewReader(in)
+	current := grid.CreateFullGrid()
+
+	// Every grid before the day's checks, and the checks made that day
+	history := []*grid.Grid{}
+	allChecks := []map[int]bool{}
+
+	fmt.Fprintln(out, "The fox is hiding in one of", len(current.Values), "holes.")
+	fmt.Fprintln(out, "Each day you can check up to", checks, "of them, and each night the fox moves.")
+	fmt.Fprintln(out, "Enter the holes to check separated by spaces or commas, or q to give up.")
+
+	for day := 1; maxDays <= 0 || day <= maxDays; day++ {
+
+		fmt.Fprintln(out)
+		fmt.Fprint(out, formatBoard(current, showPossible))
+		fmt.Fprintf(out, "Day %d, holes to check: ", day)
+
+		dayChecks, quit, err := readChecks(reader, checks)
+		for err != nil {
+			fmt.Fprintln(out, err)
+			fmt.Fprintf(out, "Day %d, holes to check: ", day)
+			dayChecks, quit, err = readChecks(reader, checks)
+		}
+		if quit {
+			break
+		}
+
+		next := current.PropgateWithChecks(dayChecks)
+		history = append(history, current)
+		allChecks = append(allChecks, dayChecks)
+
+		if next.Escaped {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "The fox escaped on day", day)
+			fmt.Fprintln(out, "Fox's Path:", formatPath(foxPath(history, allChecks, true)))
+			return false
+		}
+
+		if next.NFoxes() == 0 {
+			fmt.Fprintln(out)
+			fmt.Fprintln(out, "You caught the fox on day", day)
+			return true
+		}
+
+		current = next
+
+	}
+
+	history = append(history, current)
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "The fox got away")
+	fmt.Fprintln(out, "Fox's Path:", formatPath(foxPath(history, allChecks, false)))
+	return false
+
+}
+
+/*
+	Reads a day of checks from the player. Returns true if the
+	player gave up, or an error if the checks aren't allowed.
+*/
+func readChecks(reader *bufio.Reader, checks int) (map[int]bool, bool, error) {
+
+	line, err := reader.ReadString('\n')
+	if err != nil && line == "" {
+		return nil, true, nil
+	}
+
+	line = strings.TrimSpace(line)
+	if line == "q" {
+		return nil, true, nil
+	}
+
+	dayChecks := map[int]bool{}
+	fields := strings.FieldsFunc(line, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\t'
+	})
+	for _, field := range fields {
+		hole, err := strconv.Atoi(field)
+		if err != nil {
+			return nil, false, fmt.Errorf("invalid hole %q", field)
+		}
+		if hole < 0 || hole >= len(grid.BaseGrid.Connections) {
+			return nil, false, fmt.Errorf("hole %d isn't one of the %d holes", hole, len(grid.BaseGrid.Connections))
+		}
+		dayChecks[hole] = true
+	}
+
+	if len(dayChecks) > checks {
+		return nil, false, fmt.Errorf("at most %d checks can be made each day", checks)
+	}
+	if !grid.Constraints.Allows(dayChecks) {
+		return nil, false, fmt.Errorf("those holes can't be checked together")
+	}
+
+	return dayChecks, false, nil
+
+}
+
+/*
+	Works backwards to find a hole for the fox on each day which
+	avoids every check. The last grid in the history is where the
+	fox ends up, unless it escaped, in which case it's in an exit
+	on the last day of checks.
+*/
+func foxPath(history []*grid.Grid, allChecks []map[int]bool, escaped bool) []int {
+
+	last := len(history) - 1
+	position := -1
+	if escaped {
+		for _, exit := range grid.BaseGrid.Exits {
+			if history[last].Values[exit] && !allChecks[last][exit] {
+				position = exit
+				break
+			}
+		}
+	} else {
+		for cell, value := range history[last].Values {
+			if value {
+				position = cell
+				break
+			}
+		}
+	}
+
+	path := []int{position}
+	for day := last - 1; day >= 0; day-- {
+		moves := grid.BaseGrid.MovesOn(history[day].Day)
+	cellLoop:
+		for cell, value := range history[day].Values {
+			if !value || allChecks[day][cell] {
+				continue
+			}
+			for _, j := range moves[cell] {
+				if j == position {
+					position = cell
+					break cellLoop
+				}
+			}
+		}
+		path = append([]int{position}, path...)
+	}
+
+	return path
+
+}
+
+// Formats the holes of a path like "3 -> 4 -> 5"
+func formatPath(path []int) string {
+	text := []string{}
+	for _, cell := range path {
+		text = append(text, fmt.Sprint(cell))
+	}
+	return strings.Join(text, " -> ")
+}
+
+/*
+	Draws the board. Grids with 1 or 2 dimensional coordinates are
+	laid out in rows, anything else (including boards without a
+	coordinate for every hole) is a single list of holes. Holes
+	the fox could be in are marked with a * when showPossible is true.
+*/
+func formatBoard(g *grid.Grid, showPossible bool) string {
+
+	label := func(cell int) string {
+		text := fmt.Sprintf("%3d", cell)
+		if showPossible && g.Values[cell] {
+			return text + "*"
+		}
+		return text + " "
+	}
+
+	coordinates := grid.BaseGrid.Coordinates
+	layout := map[[2]int]int{}
+	width, height := 0, 0
+	if len(coordinates) != len(g.Values) {
+		layout = nil
+	}
+	for cell, coordinate := range coordinates {
+		if layout == nil || len(coordinate) == 0 || len(coordinate) > 2 || coordinate[0] < 0 || (len(coordinate) == 2 && coordinate[1] < 0) {
+			layout = nil
+			break
+		}
+		location := [2]int{coordinate[0], 0}
+		if len(coordinate) == 2 {
+			location[1] = coordinate[1]
+		}
+		if _, exists := layout[location]; exists {
+			layout = nil
+			break
+		}
+		layout[location] = cell
+		if location[0] + 1 > width {
+			width = location[0] + 1
+		}
+		if location[1] + 1 > height {
+			height = location[1] + 1
+		}
+	}
+
+	lines := []string{}
+	if layout == nil || len(coordinates) == 0 {
+		line := ""
+		for cell := range g.Values {
+			line += label(cell)
+		}
+		return line + "\n"
+	}
+
+	for y := 0; y < height; y++ {
+		line := ""
+		for x := 0; x < width; x++ {
+			if cell, exists := layout[[2]int{x, y}]; exists {
+				line += label(cell)
+			} else {
+				line += "    "
+			}
+		}
+		lines = append(lines, line)
+	}
+
+	return strings.Join(lines, "\n") + "\n"
+
+}
@@ -0,0 +1,67 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"io/ioutil"
+	"strings"
+	"testing"
+)
+
+func TestFormatBoard(t *testing.T) {
+
+	loaded, err := grid.ParseGridDefinition("0 - 1\n1 - 2")
+	if err != nil {
+		t.Fatal(err)
+	}
+	blank := grid.CreateLinearGrid(3)
+	blank.Coordinates = [][]int{{}, {}, {}}
+	short := grid.CreateLinearGrid(3)
+	short.Coordinates = short.Coordinates[:2]
+
+	tests := []struct {
+		name  string
+		board *grid.GridDefinition
+		want  string
+	}{
+		{"path", grid.CreateLinearGrid(3), "  0*  1*  2*\n"},
+		{"square", grid.CreatePrismGrid([]int{2, 2}), "  0*  1*\n  2*  3*\n"},
+		{"loaded", loaded, "  0*  1*  2*\n"},
+		{"blank coordinates", blank, "  0*  1*  2*\n"},
+		{"missing coordinates", short, "  0*  1*  2*\n"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, test.board)
+			if got := formatBoard(grid.CreateFullGrid(), true); got != test.want {
+				t.Errorf("got\n%q, want\n%q", got, test.want)
+			}
+		})
+	}
+
+}
+
+func TestPlayGame(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		input  string
+		caught bool
+	}{
+		{"sweep", "1\n2\n3\n3\n2\n1\n", true},
+		{"give up", "1\nq\n", false},
+		{"out of days", "1\n2\n3\n", false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+			if caught := PlayGame(strings.NewReader(test.input), ioutil.Discard, 1, 6, false); caught != test.caught {
+				t.Errorf("caught: %v, want %v", caught, test.caught)
+			}
+		})
+	}
+
+}
@@ -3,7 +3,10 @@
 package solvers
 
 import (
+	"fmt"
 	"foxhole/grid"
+	"sort"
+	"strings"
 )
 
 /*
@@ -63,4 +66,98 @@ func SetUnion(s1, s2 map[int]bool) {
 	for k := range s2 {
 		s1[k] = true
 	}
-}
\ No newline at end of file
+}
+/*
+	Returns every way of making the given number of checks among
+	the shaded cells of a grid, skipping any which aren't allowed by
+	grid.Constraints. If there are fewer shaded cells than checks
+	then all of them are checked.
+
+	When the constraints restrict which holes go together, every
+	allowed size is returned rather than just the largest, since a
+	smaller set can be allowed when no larger one containing it is.
+	For connected checks, holes the fox can't be in are also used to
+	join up the ones it can (but every set checks at least one hole
+	the fox could be in). Returns just checking nothing if there's
+	nothing else allowed.
+*/
+func checkCombinations(g *grid.Grid, checks int) []map[int]bool {
+
+	shaded := map[int]bool{}
+	cells := []int{}
+	for i, value := range g.Values {
+		if value && !grid.Constraints.Forbidden[i] {
+			shaded[i] = true
+			cells = append(cells, i)
+		}
+	}
+
+	// Holes close enough to a shaded one to be in a connected set with it
+	if grid.Constraints.Connected && checks > 1 {
+		nearby := grid.BaseGrid.NearbyCells(shaded, checks - 1)
+		cells = []int{}
+		for i := range g.Values {
+			if nearby[i] && !grid.Constraints.Forbidden[i] {
+				cells = append(cells, i)
+			}
+		}
+	}
+
+	if checks > len(cells) {
+		checks = len(cells)
+	}
+
+	restricted := grid.Constraints.Connected || grid.Constraints.MinDistance > 1
+
+	combinations := []map[int]bool{}
+	for ; checks > 0; checks-- {
+
+		for _, combination := range Combinations(len(cells), checks) {
+			cellChecks := map[int]bool{}
+			useful := false
+			for i := range combination {
+				cellChecks[cells[i]] = true
+				useful = useful || shaded[cells[i]]
+			}
+			if useful && grid.Constraints.Allows(cellChecks) {
+				combinations = append(combinations, cellChecks)
+			}
+		}
+
+		if len(combinations) > 0 && !restricted {
+			break
+		}
+
+	}
+
+	if len(combinations) == 0 {
+		return []map[int]bool{{}}
+	}
+
+	return combinations
+
+}
+
+/*
+	Formats a set of checks as a sorted, comma separated list
+*/
+func formatChecks(checks map[int]bool) string {
+
+	cells := []int{}
+	for cell := range checks {
+		cells = append(cells, cell)
+	}
+	sort.Ints(cells)
+
+	if len(cells) == 0 {
+		return "nothing"
+	}
+
+	text := []string{}
+	for _, cell := range cells {
+		text = append(text, fmt.Sprint(cell))
+	}
+
+	return strings.Join(text, ", ")
+
+}
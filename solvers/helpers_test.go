@@ -0,0 +1,89 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+/*
+	Swaps in the given board with the default rules for the rest of
+	a test, putting everything back once it's done
+*/
+func useBoard(t *testing.T, d *grid.GridDefinition) {
+
+	base, rules, constraints, goal, reliability := grid.BaseGrid, grid.FoxRules, grid.Constraints, grid.HuntGoal, grid.CheckReliability
+	t.Cleanup(func() {
+		grid.BaseGrid, grid.FoxRules, grid.Constraints, grid.HuntGoal, grid.CheckReliability = base, rules, constraints, goal, reliability
+	})
+
+	grid.BaseGrid = d
+	grid.FoxRules = grid.DefaultRules
+	grid.Constraints = grid.CheckConstraints{}
+	grid.HuntGoal = grid.Goal{}
+	grid.CheckReliability = grid.Reliability{}
+
+}
+
+func setConstraints(t *testing.T, constraints grid.CheckConstraints) {
+	if err := grid.SetConstraints(constraints); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestCheckCombinations(t *testing.T) {
+
+	tests := []struct {
+		name        string
+		constraints grid.CheckConstraints
+		checks      int
+		want        int
+	}{
+		// Every pair of the 5 holes
+		{"unconstrained", grid.CheckConstraints{}, 2, 10},
+
+		// 3 pairs at least 3 apart, plus every single hole since none of them fit in a pair with all the others
+		{"min distance", grid.CheckConstraints{MinDistance: 3}, 2, 8},
+
+		// Every connected set of 1 or 2 holes
+		{"connected", grid.CheckConstraints{Connected: true}, 2, 9},
+
+		{"forbidden", grid.CheckConstraints{Forbidden: map[int]bool{0: true, 4: true}}, 2, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+			setConstraints(t, test.constraints)
+			combinations := checkCombinations(grid.CreateFullGrid(), test.checks)
+			if len(combinations) != test.want {
+				t.Errorf("got %d check sets %v, want %d", len(combinations), combinations, test.want)
+			}
+			for _, checks := range combinations {
+				if !grid.Constraints.Allows(checks) {
+					t.Errorf("%v isn't allowed", checks)
+				}
+			}
+		})
+	}
+
+}
+
+func TestCheckCombinationsConnectors(t *testing.T) {
+
+	// The fox can only be at either end of a path of 3, so the middle has to join them
+	useBoard(t, grid.CreateLinearGrid(3))
+	setConstraints(t, grid.CheckConstraints{Connected: true})
+
+	g := grid.CreateBlankGrid()
+	g.Values[0], g.Values[2] = true, true
+
+	for _, checks := range checkCombinations(g, 3) {
+		if checks[0] && checks[2] {
+			return
+		}
+	}
+	t.Error("no check set covers both ends")
+
+}
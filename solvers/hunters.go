@@ -0,0 +1,205 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"fmt"
+	"foxhole/grid"
+	"sort"
+)
+
+/*
+	Search state for hunters who have to walk around the board.
+	Each day every hunter moves to a neighboring hole (or stays
+	put if allowed) and then checks the hole they're standing on.
+	Hunters can share a hole, both when they're placed and when
+	they move, though a shared hole is only one check.
+*/
+type huntersState struct {
+	grid *grid.Grid
+
+	// Where each hunter is standing, or nil before they've been placed
+	hunters []int
+
+	// Number of hunters to place on the first day if hunters is nil
+	nHunters int
+
+	canStay bool
+}
+
+/*
+	Key which is the same for symmetric states. The hunters are
+	interchangeable, so their order doesn't matter.
+*/
+func (s *huntersState) Key() string {
+
+	if s.hunters == nil {
+		return fmt.Sprint(s.grid.Phase(), ":", s.grid.Hash(), ":*")
+	}
+
+	lowestKey := ""
+	for i, symmetry := range grid.BaseGrid.Symmetries {
+
+		// The hashed grid puts cell symmetry[p] at p, so the hunters move the same way
+		inverse := make([]int, len(symmetry))
+		for p, cell := range symmetry {
+			inverse[cell] = p
+		}
+		hunters := []int{}
+		for _, hunter := range s.hunters {
+			hunters = append(hunters, inverse[hunter])
+		}
+		sort.Ints(hunters)
+
+		key := fmt.Sprint(s.grid.Phase(), ":", s.grid.HashWith(symmetry), ":", hunters)
+		if i == 0 || key < lowestKey {
+			lowestKey = key
+		}
+
+	}
+
+	return lowestKey
+
+}
+
+func (s *huntersState) Solved() bool {
+	return !s.grid.Escaped && grid.HuntGoal.Reached(s.grid)
+}
+
+func (s *huntersState) Successors() []Step {
+
+	// Every combination of places the hunters could be standing today
+	placements := [][]int{{}}
+	if s.hunters == nil {
+		// Each placement is in order, so the same holes aren't tried twice
+		for hunter := 0; hunter < s.nHunters; hunter++ {
+			newPlacements := [][]int{}
+			for _, placement := range placements {
+				lowest := 0
+				if len(placement) > 0 {
+					lowest = placement[len(placement) - 1]
+				}
+				for cell := lowest; cell < len(s.grid.Values); cell++ {
+					newPlacements = append(newPlacements, append(append([]int{}, placement...), cell))
+				}
+			}
+			placements = newPlacements
+		}
+	} else {
+		connections := grid.BaseGrid.ConnectionsOn(s.grid.Day)
+		for _, hunter := range s.hunters {
+			options := append([]int{}, connections[hunter]...)
+			if s.canStay || len(options) == 0 {
+				options = append(options, hunter)
+			}
+
+			newPlacements := [][]int{}
+			for _, placement := range placements {
+				for _, option := range options {
+					newPlacements = append(newPlacements, append(append([]int{}, placement...), option))
+				}
+			}
+			placements = newPlacements
+		}
+	}
+
+	steps := []Step{}
+	for _, placement := range placements {
+
+		checks := map[int]bool{}
+		for _, hunter := range placement {
+			checks[hunter] = true
+		}
+		if !grid.Constraints.Allows(checks) {
+			continue
+		}
+
+		newGrid := s.grid.PropogateWithChecksAndAdd(checks)
+		if newGrid.Escaped {
+			continue
+		}
+
+		steps = append(steps, Step{
+			Checks: checks,
+			State: &huntersState{
+				grid:     newGrid,
+				hunters:  placement,
+				nHunters: s.nHunters,
+				canStay:  s.canStay,
+			},
+		})
+
+	}
+
+	return steps
+
+}
+
+/*
+	Finds the shortest hunt for hunters who start on the given holes
+	and have to walk along the connections of the board. Returns the
+	route of each hunter: the hole they check on each day. Returns
+	false if there are no hunters, or they start off the board.
+*/
+func SolveHunters(start *grid.Grid, hunters []int, canStay bool, maxDays int) ([][]int, bool) {
+
+	if len(hunters) == 0 {
+		return nil, false
+	}
+	for _, hunter := range hunters {
+		if hunter < 0 || hunter >= len(start.Values) {
+			return nil, false
+		}
+	}
+
+	state := &huntersState{
+		grid:     start,
+		hunters:  hunters,
+		nHunters: len(hunters),
+		canStay:  canStay,
+	}
+
+	return solveHunterRoutes(state, maxDays)
+
+}
+
+/*
+	Same as SolveHunters, but the hunters can pick where they start.
+	The first hole in each route is where the hunter starts.
+*/
+func SolveHuntersAnywhere(start *grid.Grid, nHunters int, canStay bool, maxDays int) ([][]int, bool) {
+
+	if nHunters <= 0 {
+		return nil, false
+	}
+
+	state := &huntersState{
+		grid:     start,
+		nHunters: nHunters,
+		canStay:  canStay,
+	}
+
+	return solveHunterRoutes(state, maxDays)
+
+}
+
+/*
+	Helper for searching and turning the result into routes
+*/
+func solveHunterRoutes(state *huntersState, maxDays int) ([][]int, bool) {
+
+	path, found := SearchPath(state, maxDays)
+	if !found {
+		return nil, false
+	}
+
+	routes := make([][]int, state.nHunters)
+	for _, step := range path {
+		for i, hunter := range step.State.(*huntersState).hunters {
+			routes[i] = append(routes[i], hunter)
+		}
+	}
+
+	return routes, true
+
+}
@@ -0,0 +1,104 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestNoHunters(t *testing.T) {
+
+	useBoard(t, grid.CreateLinearGrid(5))
+
+	if _, found := SolveHuntersAnywhere(grid.CreateFullGrid(), 0, true, 10); found {
+		t.Error("found a hunt without any hunters")
+	}
+	if _, found := SolveHunters(grid.CreateFullGrid(), []int{}, true, 10); found {
+		t.Error("found a hunt without any hunters")
+	}
+	if _, found := SolveHunters(grid.CreateFullGrid(), []int{5}, true, 10); found {
+		t.Error("found a hunt with a hunter off the board")
+	}
+
+}
+
+func TestHunterPlacements(t *testing.T) {
+
+	useBoard(t, grid.CreateLinearGrid(3))
+
+	// Every multiset of 2 holes out of 3, since hunters can share a hole
+	state := &huntersState{grid: grid.CreateFullGrid(), nHunters: 2, canStay: true}
+	placements := map[[2]int]bool{}
+	for _, step := range state.Successors() {
+		hunters := step.State.(*huntersState).hunters
+		placements[[2]int{hunters[0], hunters[1]}] = true
+	}
+	for _, placement := range [][2]int{{0, 0}, {0, 1}, {0, 2}, {1, 1}, {1, 2}, {2, 2}} {
+		if !placements[placement] {
+			t.Errorf("hunters can't start on %v", placement)
+		}
+	}
+	if len(placements) != 6 {
+		t.Errorf("got %d placements %v, want 6", len(placements), placements)
+	}
+
+}
+
+func TestSolveHunters(t *testing.T) {
+
+	tests := []struct {
+		name     string
+		board    *grid.GridDefinition
+		nHunters int
+		canStay  bool
+		found    bool
+	}{
+		{"path", grid.CreateLinearGrid(5), 1, true, true},
+
+		// A hunter who has to move never changes parity relative to the fox, so half the foxes get away
+		{"path without staying", grid.CreateLinearGrid(5), 1, false, false},
+
+		{"square", grid.CreatePrismGrid([]int{3, 3}), 2, true, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, test.board)
+			routes, found := SolveHuntersAnywhere(grid.CreateFullGrid(), test.nHunters, test.canStay, 20)
+			if found != test.found {
+				t.Fatalf("found a hunt: %v, want %v", found, test.found)
+			}
+			if !found {
+				return
+			}
+
+			// Every hunter walks along the tunnels
+			for _, route := range routes {
+				for day := 1; day < len(route); day++ {
+					from, to := route[day - 1], route[day]
+					moved := false
+					for _, j := range test.board.Connections[from] {
+						moved = moved || j == to
+					}
+					if !moved && !(test.canStay && from == to) {
+						t.Errorf("hunter went from %d to %d", from, to)
+					}
+				}
+			}
+
+			// And the holes they stand on catch the fox
+			strategy := make([]map[int]bool, len(routes[0]))
+			for day := range strategy {
+				strategy[day] = map[int]bool{}
+				for _, route := range routes {
+					strategy[day][route[day]] = true
+				}
+			}
+			if err := VerifyStrategy(grid.CreateFullGrid(), strategy, test.nHunters); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,300 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"fmt"
+	"foxhole/grid"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+/*
+	Returns the chance the fox has been caught after each day of
+	following the strategy.
+*/
+func CaptureCurve(start *grid.Distribution, strategy []map[int]bool) []float64 {
+
+	curve := []float64{}
+	distribution := start
+	for _, checks := range strategy {
+		distribution, _ = distribution.PropogateWithChecks(checks)
+		curve = append(curve, 1-distribution.Remaining())
+	}
+
+	return curve
+
+}
+
+/*
+	Expected number of days to catch the fox following the
+	strategy, counting a fox which survives the whole strategy as
+	caught on the day after it ends. This is a lower bound on the
+	real expected time when the strategy doesn't always catch the fox.
+*/
+func ExpectedCaptureTime(start *grid.Distribution, strategy []map[int]bool) float64 {
+
+	expected := 1.0
+	for _, captured := range CaptureCurve(start, strategy) {
+		expected += 1 - captured
+	}
+
+	return expected
+
+}
+
+/*
+	Where the fox is likely to be if it's still free after following
+	the strategy. Every check that came up empty makes the holes it
+	checked less likely, so this is where to look next.
+*/
+func PosteriorAfter(start *grid.Distribution, strategy []map[int]bool) *grid.Distribution {
+
+	distribution := start
+	for _, checks := range strategy {
+		distribution, _ = distribution.PropogateWithChecks(checks)
+	}
+
+	return distribution.Posterior()
+
+}
+
+/*
+	Formats the capture curve with one line per day
+*/
+func FormatCaptureCurve(strategy []map[int]bool, curve []float64) string {
+
+	lines := []string{}
+	for day, checks := range strategy {
+		lines = append(lines, fmt.Sprintf("Day %d: check %s, caught %.4f", day+1, formatChecks(checks), curve[day]))
+	}
+
+	return strings.Join(lines, "\n")
+
+}
+
+/*
+	Checks the most likely holes every day. Returns the strategy
+	and its capture curve.
+*/
+func GreedyCapture(start *grid.Distribution, checks, days int) ([]map[int]bool, []float64) {
+
+	strategy := []map[int]bool{}
+	distribution := start
+	for day := 0; day < days; day++ {
+		dayChecks := mostLikely(distribution, checks)
+		strategy = append(strategy, dayChecks)
+		distribution, _ = distribution.PropogateWithChecks(dayChecks)
+	}
+
+	return strategy, CaptureCurve(start, strategy)
+
+}
+
+/*
+	Finds the strategy which maximizes the chance of catching the
+	fox within the given number of days. The random fox never
+	tells the hunter anything except that it hasn't been caught, so
+	a fixed list of checks is as good as any adaptive strategy.
+
+	This is an exhaustive search (remembering distributions it has
+	already seen), so it's only practical for small grids and few days.
+*/
+func MaximizeCapture(start *grid.Distribution, checks, days int) ([]map[int]bool, []float64) {
+
+	captures := newCaptureSearch(checks)
+	strategy := captures.strategy(start, days)
+
+	return strategy, CaptureCurve(start, strategy)
+
+}
+
+/*
+	Finds the strategy with the fewest days which catches the fox
+	with at least the given confidence. This matters when checks
+	can miss (see grid.CheckReliability), since then the fox may
+	never be caught for certain. Searches one more day at a time up
+	to maxDays, returning false if the confidence can't be reached.
+
+	Like MaximizeCapture this is exhaustive. For longer hunts use
+	MinimizeCaptureTime, which stops at a confidence as well.
+*/
+func FewestDaysToConfidence(start *grid.Distribution, checks int, confidence float64, maxDays int) ([]map[int]bool, []float64, bool) {
+
+	captures := newCaptureSearch(checks)
+	for days := 1; days <= maxDays; days++ {
+		if captures.best(start, days) >= confidence-1e-12 {
+			strategy := captures.strategy(start, days)
+			return strategy, CaptureCurve(start, strategy), true
+		}
+	}
+
+	return nil, nil, false
+
+}
+
+/*
+	Exhaustive search for the most probability that can be caught
+	in a number of days. The best result for each distribution
+	and number of days is remembered, since different orders of
+	checks often lead to the same distribution.
+*/
+type captureSearch struct {
+	checks int
+	known  map[string]float64
+}
+
+func newCaptureSearch(checks int) *captureSearch {
+	return &captureSearch{
+		checks: checks,
+		known:  map[string]float64{},
+	}
+}
+
+/*
+	Key for a distribution with a number of days left (and its
+	phase of the BaseGrid schedule). The
+	probabilities are rounded so that floating point error doesn't
+	stop equal distributions from matching.
+*/
+func captureKey(distribution *grid.Distribution, days int) string {
+	key := strconv.AppendInt(nil, int64(days), 10)
+	key = append(key, ':')
+	key = strconv.AppendInt(key, int64(grid.BaseGrid.Phase(distribution.Day)), 10)
+	for _, p := range distribution.Probabilities {
+		key = append(key, ',')
+		key = strconv.AppendInt(key, int64(math.Round(p*1e10)), 10)
+	}
+	return string(key)
+}
+
+/*
+	Returns the most probability that can be caught within days
+*/
+func (search *captureSearch) best(distribution *grid.Distribution, days int) float64 {
+
+	if days == 0 || distribution.Remaining() == 0 {
+		return 0
+	}
+
+	key := captureKey(distribution, days)
+	if captured, exists := search.known[key]; exists {
+		return captured
+	}
+
+	best := 0.0
+	for _, dayChecks := range checkCombinations(distribution.Grid(), search.checks) {
+		next, caughtToday := distribution.PropogateWithChecks(dayChecks)
+		if captured := caughtToday + search.best(next, days-1); captured > best {
+			best = captured
+		}
+	}
+
+	search.known[key] = best
+	return best
+
+}
+
+/*
+	Rebuilds the strategy which catches the most probability
+	within days, stopping early if everything has been caught.
+*/
+func (search *captureSearch) strategy(distribution *grid.Distribution, days int) []map[int]bool {
+
+	strategy := []map[int]bool{}
+	for ; days > 0 && distribution.Remaining() > 0; days-- {
+
+		target := search.best(distribution, days)
+		for _, dayChecks := range checkCombinations(distribution.Grid(), search.checks) {
+			next, caughtToday := distribution.PropogateWithChecks(dayChecks)
+			if caughtToday+search.best(next, days-1) >= target-1e-12 {
+				strategy = append(strategy, dayChecks)
+				distribution = next
+				break
+			}
+		}
+
+	}
+
+	return strategy
+
+}
+
+/*
+	Picks each day's checks by looking ahead a few days and
+	minimizing the total chance the fox survives each of them,
+	which minimizes the expected capture time over the lookahead.
+	Stops once the fox has been caught with the given confidence
+	or after maxDays. Returns the strategy and its capture curve.
+	A lookahead of less than a day is treated as just today.
+*/
+func MinimizeCaptureTime(start *grid.Distribution, checks, lookahead int, confidence float64, maxDays int) ([]map[int]bool, []float64) {
+
+	if lookahead < 1 {
+		lookahead = 1
+	}
+
+	strategy := []map[int]bool{}
+	distribution := start
+	for day := 0; day < maxDays && 1-distribution.Remaining() < confidence; day++ {
+
+		bestSurvival := -1.0
+		var bestChecks map[int]bool
+
+		var search func(d *grid.Distribution, depth int, survival float64, first map[int]bool)
+		search = func(d *grid.Distribution, depth int, survival float64, first map[int]bool) {
+
+			if depth == lookahead {
+				if bestSurvival < 0 || survival < bestSurvival-1e-12 {
+					bestSurvival = survival
+					bestChecks = first
+				}
+				return
+			}
+
+			for _, dayChecks := range checkCombinations(d.Grid(), checks) {
+				next, _ := d.PropogateWithChecks(dayChecks)
+				if first == nil {
+					search(next, depth+1, survival+next.Remaining(), dayChecks)
+				} else {
+					search(next, depth+1, survival+next.Remaining(), first)
+				}
+			}
+
+		}
+		search(distribution, 0, 0, nil)
+
+		strategy = append(strategy, bestChecks)
+		distribution, _ = distribution.PropogateWithChecks(bestChecks)
+
+	}
+
+	return strategy, CaptureCurve(start, strategy)
+
+}
+
+/*
+	Returns the most likely holes
+*/
+func mostLikely(distribution *grid.Distribution, checks int) map[int]bool {
+
+	cells := []int{}
+	for i, p := range distribution.Probabilities {
+		if p > 0 {
+			cells = append(cells, i)
+		}
+	}
+	sort.SliceStable(cells, func(a, b int) bool {
+		return distribution.Probabilities[cells[a]] > distribution.Probabilities[cells[b]]
+	})
+
+	dayChecks := map[int]bool{}
+	for i := 0; i < checks && i < len(cells); i++ {
+		dayChecks[cells[i]] = true
+	}
+
+	return dayChecks
+
+}
@@ -0,0 +1,99 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestMinimizeCaptureTime(t *testing.T) {
+
+	useBoard(t, grid.CreateLinearGrid(5))
+	start := grid.CreateUniformDistribution(grid.CreateFullGrid())
+
+	want, wantCurve := MinimizeCaptureTime(start, 1, 1, 0.99, 10)
+
+	for _, lookahead := range []int{0, -1} {
+		strategy, curve := MinimizeCaptureTime(start, 1, lookahead, 0.99, 10)
+		for day, checks := range strategy {
+			if checks == nil {
+				t.Errorf("lookahead %d: no checks on day %d", lookahead, day+1)
+			}
+		}
+		if !reflect.DeepEqual(strategy, want) || !reflect.DeepEqual(curve, wantCurve) {
+			t.Errorf("lookahead %d: got %v, want the same as a lookahead of 1 %v", lookahead, strategy, want)
+		}
+	}
+
+}
+
+func TestMaximizeCapture(t *testing.T) {
+
+	// The sweep which always catches the fox on a path of 5 takes 6 days
+	useBoard(t, grid.CreateLinearGrid(5))
+	start := grid.CreateUniformDistribution(grid.CreateFullGrid())
+
+	strategy, curve := MaximizeCapture(start, 1, 6)
+	if len(strategy) != 6 || math.Abs(curve[len(curve) - 1] - 1) > 1e-9 {
+		t.Errorf("got %v with capture curve %v, want the fox always caught in 6 days", strategy, curve)
+	}
+
+	// Capture chances can only go up
+	for day := 1; day < len(curve); day++ {
+		if curve[day] < curve[day - 1] - 1e-12 {
+			t.Errorf("capture chance went down on day %d: %v", day+1, curve)
+		}
+	}
+
+}
+
+func TestFewestDaysToConfidence(t *testing.T) {
+
+	tests := []struct {
+		name       string
+		miss       float64
+		confidence float64
+		days       int
+		found      bool
+	}{
+		{"reliable", 0, 0.85, 4, true},
+		{"certain", 0, 1, 6, true},
+
+		// The checks that miss need the full sweep to get there
+		{"misses", 0.2, 0.85, 6, true},
+
+		// A fox can always slip past checks that miss
+		{"misses certain", 0.2, 1, 0, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+			grid.CheckReliability = grid.Reliability{Miss: test.miss}
+			start := grid.CreateUniformDistribution(grid.CreateFullGrid())
+
+			strategy, curve, found := FewestDaysToConfidence(start, 1, test.confidence, 7)
+			if found != test.found {
+				t.Fatalf("found a strategy %v, want %v", found, test.found)
+			}
+			if !found {
+				return
+			}
+			if len(strategy) != test.days {
+				t.Errorf("strategy %v takes %d days, want %d", strategy, len(strategy), test.days)
+			}
+			if curve[len(curve) - 1] < test.confidence - 1e-9 {
+				t.Errorf("strategy only catches the fox with probability %v", curve[len(curve) - 1])
+			}
+
+			// Wherever the fox is if it got away, it's somewhere
+			if posterior := PosteriorAfter(start, strategy); test.confidence < 1 && math.Abs(posterior.Remaining() - 1) > 1e-9 {
+				t.Errorf("posterior adds up to %v, want 1", posterior.Remaining())
+			}
+		})
+	}
+
+}
@@ -0,0 +1,393 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+/*
+	A state of the hunt for the generic search. Variants of the
+	puzzle which need more than a single grid to describe what
+	the hunter knows implement this.
+*/
+type State interface {
+
+	// Key which is the same for states that are symmetric to each other
+	Key() string
+
+	// Whether or not the hunter has won
+	Solved() bool
+
+	// Every state the hunt could be in tomorrow, with the checks made today
+	Successors() []Step
+}
+
+/*
+	A single day of a strategy: the checks made that day and
+	the state the hunt ends up in.
+*/
+type Step struct {
+	Checks map[int]bool
+	State  State
+}
+
+/*
+	Breadth first search from the start state. Returns the checks
+	to make on each day of the shortest strategy which wins, and
+	whether or not one was found. The search gives up after maxDays
+	days (or once every reachable state is exhausted if maxDays is
+	not positive).
+*/
+func Search(start State, maxDays int) ([]map[int]bool, bool) {
+
+	path, found := SearchPath(start, maxDays)
+	if !found {
+		return nil, false
+	}
+
+	strategy := []map[int]bool{}
+	for _, step := range path {
+		strategy = append(strategy, step.Checks)
+	}
+
+	return strategy, true
+
+}
+
+/*
+	Same as Search, but returns every step of the strategy so
+	the states along the way can be inspected.
+*/
+func SearchPath(start State, maxDays int) ([]Step, bool) {
+
+	if start.Solved() {
+		return []Step{}, true
+	}
+
+	// Track how each state was first reached so the strategy can be rebuilt
+	type node struct {
+		parent *node
+		checks map[int]bool
+		state  State
+	}
+
+	visited := map[string]bool{start.Key(): true}
+	frontier := []*node{{state: start}}
+
+	for day := 1; len(frontier) > 0 && (maxDays <= 0 || day <= maxDays); day++ {
+
+		newFrontier := []*node{}
+		for _, current := range frontier {
+			for _, step := range current.state.Successors() {
+
+				next := &node{parent: current, checks: step.Checks, state: step.State}
+				if step.State.Solved() {
+					path := make([]Step, day)
+					for n := next; n.parent != nil; n = n.parent {
+						day--
+						path[day] = Step{Checks: n.checks, State: n.state}
+					}
+					return path, true
+				}
+
+				key := step.State.Key()
+				if !visited[key] {
+					visited[key] = true
+					newFrontier = append(newFrontier, next)
+				}
+
+			}
+		}
+		frontier = newFrontier
+
+	}
+
+	return nil, false
+
+}
+
+/*
+	Uniform cost search from the start state, where each step costs
+	whatever the cost function says. When lexicographic is true the
+	fewest days wins and cost only breaks ties, otherwise the lowest
+	total cost wins and days only break ties. Returns every step of
+	the cheapest strategy which wins, its total cost and whether or
+	not one was found within maxDays (no limit if not positive).
+*/
+func CheapestPath(start State, cost func(Step) float64, lexicographic bool, maxDays int) ([]Step, float64, bool) {
+
+	settled := map[string]bool{}
+	best := map[string]*costNode{}
+
+	/*
+		With a day limit a cheap way of reaching a state can take too
+		long to finish from, while a pricier but quicker one would have
+		made it, so the same state on different days is kept apart.
+	*/
+	nodeKey := func(state State, days int) string {
+		if maxDays > 0 {
+			return fmt.Sprint(state.Key(), ":", days)
+		}
+		return state.Key()
+	}
+
+	root := &costNode{state: start}
+	best[nodeKey(start, 0)] = root
+	queue := &costQueue{lexicographic: lexicographic}
+	heap.Push(queue, root)
+
+	for queue.Len() > 0 {
+
+		current := heap.Pop(queue).(*costNode)
+		key := nodeKey(current.state, current.days)
+		if settled[key] {
+			continue
+		}
+		settled[key] = true
+
+		if current.state.Solved() {
+			path := make([]Step, current.days)
+			for n := current; n.parent != nil; n = n.parent {
+				path[n.days - 1] = Step{Checks: n.checks, State: n.state}
+			}
+			return path, current.cost, true
+		}
+
+		if maxDays > 0 && current.days >= maxDays {
+			continue
+		}
+
+		for _, step := range current.state.Successors() {
+
+			next := &costNode{
+				parent: current,
+				checks: step.Checks,
+				state:  step.State,
+				days:   current.days + 1,
+				cost:   current.cost + cost(step),
+			}
+
+			nextKey := nodeKey(step.State, next.days)
+			if settled[nextKey] {
+				continue
+			}
+			if previous, exists := best[nextKey]; exists && !queue.less(next, previous) {
+				continue
+			}
+			best[nextKey] = next
+			heap.Push(queue, next)
+
+		}
+
+	}
+
+	return nil, 0, false
+
+}
+
+// A state reached by the uniform cost search and how it got there
+type costNode struct {
+	parent *costNode
+	checks map[int]bool
+	state  State
+	days   int
+	cost   float64
+}
+
+/*
+	Priority queue of search nodes, cheapest first. Implements
+	heap.Interface.
+*/
+type costQueue struct {
+	nodes         []*costNode
+	lexicographic bool
+}
+
+func (q *costQueue) less(a, b *costNode) bool {
+	if q.lexicographic && a.days != b.days {
+		return a.days < b.days
+	}
+	if a.cost != b.cost {
+		return a.cost < b.cost
+	}
+	return a.days < b.days
+}
+
+func (q *costQueue) Len() int           { return len(q.nodes) }
+func (q *costQueue) Less(i, j int) bool { return q.less(q.nodes[i], q.nodes[j]) }
+func (q *costQueue) Swap(i, j int)      { q.nodes[i], q.nodes[j] = q.nodes[j], q.nodes[i] }
+func (q *costQueue) Push(x interface{}) { q.nodes = append(q.nodes, x.(*costNode)) }
+
+func (q *costQueue) Pop() interface{} {
+	last := q.nodes[len(q.nodes) - 1]
+	q.nodes = q.nodes[:len(q.nodes) - 1]
+	return last
+}
+
+/*
+	A state of the hunt for adaptive strategies, where the checks
+	made each day can tell the hunter something about where the
+	fox is.
+*/
+type AdaptiveState interface {
+
+	// Key which is the same for states that are symmetric to each other
+	Key() string
+
+	// Whether or not the hunter has won
+	Solved() bool
+
+	// Every choice of checks for today along with what could come of it
+	Choices() []Choice
+}
+
+/*
+	A choice of checks for a day and every outcome it could have.
+	The outcomes are the states the hunt could be in tomorrow, one
+	for each observation the hunter could make today.
+*/
+type Choice struct {
+	Checks   map[int]bool
+	Outcomes []Outcome
+}
+
+type Outcome struct {
+
+	// Human readable description of what the hunter observed
+	Observation string
+
+	State AdaptiveState
+}
+
+/*
+	An adaptive strategy. The checks are made, and then the hunter
+	follows the branch matching whatever they observed. Outcomes
+	where the hunter has already won don't have a next tree.
+*/
+type DecisionTree struct {
+	Checks   map[int]bool
+	Branches []DecisionBranch
+}
+
+type DecisionBranch struct {
+	Observation string
+	Next        *DecisionTree
+}
+
+/*
+	Returns the most days the strategy can take to win
+*/
+func (tree *DecisionTree) Days() int {
+
+	if tree == nil {
+		return 0
+	}
+
+	longest := 0
+	for _, branch := range tree.Branches {
+		if days := branch.Next.Days(); days > longest {
+			longest = days
+		}
+	}
+
+	return longest + 1
+
+}
+
+/*
+	Prints the tree with one day per level of indentation
+*/
+func (tree *DecisionTree) String() string {
+
+	if tree == nil {
+		return "Caught\n"
+	}
+
+	text := ""
+	var write func(t *DecisionTree, day int, indent string)
+	write = func(t *DecisionTree, day int, indent string) {
+		text += fmt.Sprintf("%sDay %d: check %s\n", indent, day, formatChecks(t.Checks))
+		if len(t.Branches) == 0 {
+			text += fmt.Sprintf("%s  fox caught\n", indent)
+		}
+		for _, branch := range t.Branches {
+			if branch.Next == nil {
+				text += fmt.Sprintf("%s  %s: caught\n", indent, branch.Observation)
+				continue
+			}
+			text += fmt.Sprintf("%s  %s:\n", indent, branch.Observation)
+			write(branch.Next, day+1, indent+"    ")
+		}
+	}
+	write(tree, 1, "")
+
+	return text
+
+}
+
+/*
+	Depth first AND-OR search with iterative deepening. The hunter
+	picks the checks (OR) and the strategy has to win for every
+	outcome (AND). Returns the decision tree with the fewest days in
+	the worst case, and whether or not one within maxDays was found.
+*/
+func AndOrSearch(start AdaptiveState, maxDays int) (*DecisionTree, bool) {
+
+	if start.Solved() {
+		return nil, true
+	}
+
+	// Most days each state is known to fail within
+	failed := map[string]int{}
+
+	for days := 1; days <= maxDays; days++ {
+		if tree := andOrSearch(start, days, failed); tree != nil {
+			return tree, true
+		}
+	}
+
+	return nil, false
+
+}
+
+/*
+	Depth limited part of AndOrSearch. Returns nil if there's no
+	strategy which wins within the given number of days.
+*/
+func andOrSearch(state AdaptiveState, days int, failed map[string]int) *DecisionTree {
+
+	key := state.Key()
+	if days <= failed[key] {
+		return nil
+	}
+
+choiceLoop:
+	for _, choice := range state.Choices() {
+
+		tree := &DecisionTree{Checks: choice.Checks}
+		for _, outcome := range choice.Outcomes {
+
+			branch := DecisionBranch{Observation: outcome.Observation}
+			if !outcome.State.Solved() {
+				if days == 1 {
+					continue choiceLoop
+				}
+				branch.Next = andOrSearch(outcome.State, days-1, failed)
+				if branch.Next == nil {
+					continue choiceLoop
+				}
+			}
+			tree.Branches = append(tree.Branches, branch)
+
+		}
+
+		return tree
+
+	}
+
+	failed[key] = days
+	return nil
+
+}
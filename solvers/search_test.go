@@ -0,0 +1,128 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestLazyFox(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		rules  grid.Rules
+		checks int
+		days   int
+		found  bool
+	}{
+		{"moving fox", grid.DefaultRules, 1, 2, true},
+
+		// Checking the middle never catches a fox that can wait at either end
+		{"lazy fox", grid.Rules{Stay: true, Steps: 1}, 1, 0, false},
+		{"lazy fox with 2 checks", grid.Rules{Stay: true, Steps: 1}, 2, 2, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(3))
+			grid.FoxRules = test.rules
+
+			strategy, found := Search(&gridState{grid: grid.CreateFullGrid(), solver: Brute, checks: test.checks}, 8)
+			if found != test.found {
+				t.Fatalf("found a strategy %v, want %v", found, test.found)
+			}
+			if !found {
+				return
+			}
+			if len(strategy) != test.days {
+				t.Errorf("strategy %v takes %d days, want %d", strategy, len(strategy), test.days)
+			}
+			if err := VerifyStrategy(grid.CreateFullGrid(), strategy, test.checks); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+
+}
+
+func TestScheduledHunt(t *testing.T) {
+
+	// Tunnels on the left half of a path of 5 are open on even days and the right half on odd days
+	useBoard(t, grid.CreatePeriodicGrid(grid.CreateLinearGrid(5), 2, func(day, from, to int) bool {
+		return (from <= 2 && to <= 2) == (day == 0)
+	}))
+
+	strategy, found := Search(&gridState{grid: grid.CreateFullGrid(), solver: Brute, checks: 1}, 10)
+	if !found {
+		t.Fatal("no strategy found")
+	}
+	if len(strategy) != 4 {
+		t.Errorf("strategy %v takes %d days, want 4", strategy, len(strategy))
+	}
+	if err := VerifyStrategy(grid.CreateFullGrid(), strategy, 1); err != nil {
+		t.Error(err)
+	}
+
+}
+
+func TestSearchFromStart(t *testing.T) {
+
+	useBoard(t, grid.CreateLinearGrid(5))
+
+	// A fox known to start at the end can be cornered, rather than needing the 6 day sweep
+	start, err := grid.ParseGrid("0,1")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strategy, found := Search(&gridState{grid: start, solver: Brute, checks: 1}, 10)
+	if !found {
+		t.Fatal("no strategy found")
+	}
+	if len(strategy) != 2 {
+		t.Errorf("strategy %v takes %d days, want 2", strategy, len(strategy))
+	}
+	if err := VerifyStrategy(start, strategy, 1); err != nil {
+		t.Error(err)
+	}
+
+}
+
+func TestHuntGoal(t *testing.T) {
+
+	tests := []struct {
+		name string
+		goal grid.Goal
+		days int
+	}{
+		{"catch", grid.Goal{}, 10},
+		{"two holes", grid.Goal{MaxHoles: 2}, 7},
+		{"three holes", grid.Goal{MaxHoles: 3}, 5},
+		{"radius", grid.Goal{Radius: 2}, 3},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(7))
+			grid.HuntGoal = test.goal
+
+			strategy, found := Search(&gridState{grid: grid.CreateFullGrid(), solver: Brute, checks: 1}, 20)
+			if !found {
+				t.Fatal("no strategy found")
+			}
+			if len(strategy) != test.days {
+				t.Errorf("strategy %v takes %d days, want %d", strategy, len(strategy), test.days)
+			}
+			if err := VerifyStrategy(grid.CreateFullGrid(), strategy, 1); err != nil {
+				t.Error(err)
+			}
+
+			// A day less never narrows the fox down enough
+			if test.days > 1 && VerifyStrategy(grid.CreateFullGrid(), strategy[:len(strategy) - 1], 1) == nil {
+				t.Errorf("strategy %v works without its last day", strategy)
+			}
+		})
+	}
+
+}
@@ -0,0 +1,116 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"fmt"
+	"foxhole/grid"
+)
+
+/*
+	Brute force solver for sensors which clear every hole within
+	the radius of each probe. Can be passed straight to Solve.
+*/
+func SensorBrute(radius int) SolverFunction {
+	return func(originalGrid *grid.Grid, checks int) []*grid.Grid {
+
+		resultingGrids := []*grid.Grid{}
+		for _, probes := range checkCombinations(originalGrid.SensorReach(radius), checks) {
+			newGrid := originalGrid.PropogateWithSensors(probes, radius)
+			newGrid.AddChecks(probes)
+			resultingGrids = append(resultingGrids, newGrid)
+		}
+
+		return resultingGrids
+
+	}
+}
+
+/*
+	Search state for informative probes
+*/
+type probeState struct {
+	grid   *grid.Grid
+	checks int
+	radius int
+	noisy  bool
+}
+
+func (s *probeState) Key() string {
+	return fmt.Sprint(s.grid.Phase(), ":", s.grid.Hash())
+}
+
+func (s *probeState) Solved() bool {
+	return grid.HuntGoal.Reached(s.grid)
+}
+
+func (s *probeState) Choices() []Choice {
+
+	choices := []Choice{}
+	for _, probes := range checkCombinations(s.grid.SensorReach(s.radius), s.checks) {
+
+		outcomes := s.grid.Probe(probes, s.radius)
+		if s.noisy {
+			outcomes = s.grid.NoisyProbe(probes, s.radius)
+		}
+
+		choice := Choice{Checks: probes}
+		for _, outcome := range outcomes {
+			choice.Outcomes = append(choice.Outcomes, Outcome{
+				Observation: describeAnswers(outcome.Answers),
+				State: &probeState{
+					grid:   outcome.Grid,
+					checks: s.checks,
+					radius: s.radius,
+					noisy:  s.noisy,
+				},
+			})
+		}
+		choices = append(choices, choice)
+
+	}
+
+	return choices
+
+}
+
+/*
+	Helper for describing the answers from a set of probes
+*/
+func describeAnswers(answers map[int]bool) string {
+	if len(answers) == 0 {
+		return "fox not near any probe"
+	}
+	return "fox near " + formatChecks(answers)
+}
+
+/*
+	Finds the adaptive strategy with the fewest days (in the worst
+	case) for informative probes with the given radius, starting
+	from the given grid. Returns false if no strategy within maxDays
+	exists.
+*/
+func SolveProbes(start *grid.Grid, checks, radius, maxDays int) (*DecisionTree, bool) {
+	return solveProbes(start, checks, radius, false, maxDays)
+}
+
+/*
+	Same as SolveProbes, but the probes can miss a fox which is in
+	range (though a fox in the probed hole itself is always caught).
+*/
+func SolveNoisyProbes(start *grid.Grid, checks, radius, maxDays int) (*DecisionTree, bool) {
+	return solveProbes(start, checks, radius, true, maxDays)
+}
+
+func solveProbes(start *grid.Grid, checks, radius int, noisy bool, maxDays int) (*DecisionTree, bool) {
+
+	state := &probeState{
+		grid:   start,
+		checks: checks,
+		radius: radius,
+		noisy:  noisy,
+	}
+
+	return AndOrSearch(state, maxDays)
+
+}
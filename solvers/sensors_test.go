@@ -0,0 +1,130 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestSensorBrute(t *testing.T) {
+
+	tests := []struct {
+		length int
+		radius int
+		days   int
+	}{
+		// A radius of 0 is the same as checking holes
+		{5, 0, 6},
+		{5, 1, 2},
+		{5, 2, 1},
+		{9, 1, 4},
+	}
+
+	for _, test := range tests {
+		useBoard(t, grid.CreateLinearGrid(test.length))
+
+		strategy, found := Search(&gridState{grid: grid.CreateFullGrid(), solver: SensorBrute(test.radius), checks: 1}, 20)
+		if !found {
+			t.Fatalf("no strategy on a path of %d with radius %d", test.length, test.radius)
+		}
+		if len(strategy) != test.days {
+			t.Errorf("path of %d with radius %d: strategy %v takes %d days, want %d", test.length, test.radius, strategy, len(strategy), test.days)
+		}
+
+		// Playing the sensors through catches the fox
+		current := grid.CreateFullGrid()
+		for _, probes := range strategy {
+			current = current.PropogateWithSensors(probes, test.radius)
+		}
+		if current.NFoxes() != 0 {
+			t.Errorf("path of %d with radius %d: fox could still be in %v", test.length, test.radius, current.Values)
+		}
+	}
+
+}
+
+/*
+	Follows every branch of the tree against the real probe outcomes,
+	making sure each one ends with the fox caught
+*/
+func checkTree(t *testing.T, g *grid.Grid, tree *DecisionTree, radius int, noisy bool) {
+
+	t.Helper()
+	if tree == nil {
+		if g.NFoxes() != 0 {
+			t.Errorf("tree says the fox is caught, but it could be in %v", g.Values)
+		}
+		return
+	}
+
+	outcomes := g.Probe(tree.Checks, radius)
+	if noisy {
+		outcomes = g.NoisyProbe(tree.Checks, radius)
+	}
+
+	for _, outcome := range outcomes {
+		observation := describeAnswers(outcome.Answers)
+		matched := false
+		for _, branch := range tree.Branches {
+			if branch.Observation == observation {
+				checkTree(t, outcome.Grid, branch.Next, radius, noisy)
+				matched = true
+			}
+		}
+		if !matched {
+			t.Errorf("tree has no branch for %q after checking %v", observation, tree.Checks)
+		}
+	}
+
+}
+
+func TestSolveProbes(t *testing.T) {
+
+	tests := []struct {
+		name   string
+		radius int
+		noisy  bool
+		days   int
+	}{
+		{"plain checks", 0, false, 6},
+
+		// Hearing where the fox is lets the hunter follow it
+		{"probes", 1, false, 4},
+
+		// Probes which can miss are no better than plain checks here
+		{"noisy probes", 1, true, 6},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+
+			solve := SolveProbes
+			if test.noisy {
+				solve = SolveNoisyProbes
+			}
+			tree, found := solve(grid.CreateFullGrid(), 1, test.radius, 10)
+			if !found {
+				t.Fatal("no strategy found")
+			}
+			if tree.Days() != test.days {
+				t.Errorf("tree takes %d days, want %d\n%s", tree.Days(), test.days, tree)
+			}
+			checkTree(t, grid.CreateFullGrid(), tree, test.radius, test.noisy)
+		})
+	}
+
+	// Nothing to do once the fox can't be anywhere
+	useBoard(t, grid.CreateLinearGrid(5))
+	tree, found := SolveProbes(grid.CreateBlankGrid(), 1, 1, 10)
+	if !found || tree != nil || tree.String() != "Caught\n" {
+		t.Errorf("got %v and %v for an empty board, want no tree", tree, found)
+	}
+
+	// Too few days
+	if _, found := SolveProbes(grid.CreateFullGrid(), 1, 1, 3); found {
+		t.Error("found a strategy in 3 days")
+	}
+
+}
@@ -0,0 +1,168 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"fmt"
+	"foxhole/grid"
+)
+
+/*
+	Replays a strategy from the start grid to make sure it's legal
+	and that it actually catches the fox (or meets grid.HuntGoal).
+	Every day can use at most the given number of checks and has
+	to be allowed by grid.Constraints. Returns nil if the strategy
+	works.
+*/
+func VerifyStrategy(start *grid.Grid, strategy []map[int]bool, checks int) error {
+
+	g := start
+	for day, dayChecks := range strategy {
+
+		if len(dayChecks) > checks {
+			return fmt.Errorf("day %d makes %d checks but only %d are allowed", day+1, len(dayChecks), checks)
+		}
+		if !grid.Constraints.Allows(dayChecks) {
+			return fmt.Errorf("day %d checks %s which isn't allowed", day+1, formatChecks(dayChecks))
+		}
+
+		g = g.PropgateWithChecks(dayChecks)
+		if g.Escaped {
+			return fmt.Errorf("the fox can escape on day %d", day+1)
+		}
+
+	}
+
+	if !grid.HuntGoal.Reached(g) {
+		return fmt.Errorf("the fox could still be in %d holes", g.NFoxes())
+	}
+
+	return nil
+
+}
+
+/*
+	Combines the strategies for each parity class into a single
+	strategy which catches the fox no matter which class it started
+	in. Strategies are played one after another: whichever class
+	the fox could still be in decides which strategy comes next,
+	since a strategy for a whole class also works for any part of
+	it. The result is checked with VerifyStrategy before it's
+	returned.
+*/
+func UnifyStrategies(classes []*grid.Grid, strategies [][]map[int]bool, checks int) ([]map[int]bool, error) {
+
+	// Narrowing down each class doesn't narrow down the whole board
+	if !grid.HuntGoal.IsCapture() {
+		return nil, fmt.Errorf("only strategies which catch the fox can be combined")
+	}
+
+	start, err := unionOfClasses(classes)
+	if err != nil {
+		return nil, err
+	}
+
+	unified := []map[int]bool{}
+	g := start
+	for round := 0; g.NFoxes() > 0; round++ {
+
+		// Every strategy should be needed at most once, so give up well after that
+		if round > 2 * len(classes) {
+			return nil, fmt.Errorf("the fox could still be in %d holes after every class strategy", g.NFoxes())
+		}
+
+		// Prefer a class holding every hole left, otherwise any it overlaps
+		next := -1
+		for i, class := range classes {
+			if class.Phase() != g.Phase() || !overlaps(g, class) {
+				continue
+			}
+			if next == -1 || contains(class, g) {
+				next = i
+			}
+			if contains(class, g) {
+				break
+			}
+		}
+		if next == -1 {
+			return nil, fmt.Errorf("no class strategy covers the holes left on day %d", len(unified)+1)
+		}
+
+		for _, dayChecks := range strategies[next] {
+			g = g.PropgateWithChecks(dayChecks)
+			unified = append(unified, dayChecks)
+		}
+
+	}
+
+	if err := VerifyStrategy(start, unified, checks); err != nil {
+		return nil, err
+	}
+
+	return unified, nil
+
+}
+
+/*
+	Finds a single strategy by searching from every class at once.
+	This is for when the class strategies can't be combined (see
+	UnifyStrategies), for example when the fox can escape from one
+	class while another is being hunted. The search is over the whole
+	board, so it's much slower than solving each class.
+*/
+func SearchUnified(solver SolverFunction, classes []*grid.Grid, checks int) ([]map[int]bool, error) {
+
+	start, err := unionOfClasses(classes)
+	if err != nil {
+		return nil, err
+	}
+
+	strategy, found := Search(&gridState{grid: start, solver: solver, checks: checks}, 0)
+	if !found {
+		return nil, fmt.Errorf("no strategy works for every class at once")
+	}
+
+	if err := VerifyStrategy(start, strategy, checks); err != nil {
+		return nil, err
+	}
+
+	return strategy, nil
+
+}
+
+// The grid where the fox could be in any of the classes
+func unionOfClasses(classes []*grid.Grid) (*grid.Grid, error) {
+
+	start := classes[0].Copy()
+	for _, class := range classes[1:] {
+		if class.Phase() != start.Phase() {
+			return nil, fmt.Errorf("the classes start in different phases of the schedule")
+		}
+		for i, value := range class.Values {
+			start.Values[i] = start.Values[i] || value
+		}
+	}
+
+	return start, nil
+
+}
+
+// Whether or not the fox could be in a hole of both grids
+func overlaps(a, b *grid.Grid) bool {
+	for i, value := range a.Values {
+		if value && b.Values[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// Whether or not every hole the fox could be in for b is in a
+func contains(a, b *grid.Grid) bool {
+	for i, value := range b.Values {
+		if value && !a.Values[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,81 @@
+// Copyright Clayton Brown 2020. See LICENSE file.
+
+package solvers
+
+import (
+	"foxhole/grid"
+	"testing"
+)
+
+func TestVerifyStrategy(t *testing.T) {
+
+	// Checking 1, 2, 3 and back again catches the fox on a path of 5
+	sweep := []map[int]bool{{1: true}, {2: true}, {3: true}, {3: true}, {2: true}, {1: true}}
+
+	tests := []struct {
+		name        string
+		constraints grid.CheckConstraints
+		strategy    []map[int]bool
+		checks      int
+		valid       bool
+	}{
+		{"sweep", grid.CheckConstraints{}, sweep, 1, true},
+		{"too short", grid.CheckConstraints{}, sweep[:5], 1, false},
+		{"too many checks", grid.CheckConstraints{}, []map[int]bool{{1: true, 3: true}}, 1, false},
+		{"forbidden", grid.CheckConstraints{Forbidden: map[int]bool{2: true}}, sweep, 1, false},
+		{"not connected", grid.CheckConstraints{Connected: true}, []map[int]bool{{1: true, 3: true}, {1: true, 3: true}}, 2, false},
+		{"too close", grid.CheckConstraints{MinDistance: 3}, []map[int]bool{{1: true, 2: true}, {2: true, 3: true}, {1: true, 2: true}}, 2, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			useBoard(t, grid.CreateLinearGrid(5))
+			setConstraints(t, test.constraints)
+			err := VerifyStrategy(grid.CreateFullGrid(), test.strategy, test.checks)
+			if test.valid && err != nil {
+				t.Error(err)
+			}
+			if !test.valid && err == nil {
+				t.Error("strategy should have been rejected")
+			}
+		})
+	}
+
+}
+
+func TestSearchUnified(t *testing.T) {
+
+	// The fox can get out at the end of a path of 7 while the other class is being hunted
+	useBoard(t, grid.CreateLinearGrid(7))
+	grid.BaseGrid.SetExits(0)
+
+	classes := grid.BaseGrid.ParityClasses(grid.CreateFullGrid())
+	if len(classes) != 2 {
+		t.Fatalf("got %d parity classes, want 2", len(classes))
+	}
+
+	strategies := [][]map[int]bool{}
+	for _, class := range classes {
+		strategy, found := Search(&gridState{grid: class, solver: Brute, checks: 2}, 0)
+		if !found {
+			t.Fatalf("no strategy for class %v", class.Values)
+		}
+		strategies = append(strategies, strategy)
+	}
+
+	if _, err := UnifyStrategies(classes, strategies, 2); err == nil {
+		t.Error("playing the class strategies one after another should let the fox escape")
+	}
+
+	unified, err := SearchUnified(Brute, classes, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(unified) != 5 {
+		t.Errorf("unified strategy takes %d days, want 5", len(unified))
+	}
+	if err := VerifyStrategy(grid.CreateFullGrid(), unified, 2); err != nil {
+		t.Error(err)
+	}
+
+}